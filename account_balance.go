@@ -1,8 +1,8 @@
 package binance
 
 import (
+	"context"
 	"encoding/json"
-	"log"
 	"net/http"
 )
 
@@ -18,7 +18,7 @@ type jsonCoinBalance struct {
 	UpdateTime         int64  `json:"updateTime"`
 }
 
-func (s *AccountService) getBalances() []CoinBalance {
+func (s *AccountService) getBalances(ctx context.Context) ([]CoinBalance, error) {
 
 	req := request{
 		method:     http.MethodGet,
@@ -28,16 +28,16 @@ func (s *AccountService) getBalances() []CoinBalance {
 		secType:    secTypeSigned,
 	}
 
-	body, err := s.c.callAPI(&req)
+	body, err := s.c.callAPI(ctx, &req)
 	if err != nil {
-		return []CoinBalance{}
+		return nil, err
 	}
 
 	var balanceObjList []jsonCoinBalance
 	err = json.Unmarshal(body, &balanceObjList)
 	if err != nil {
-		log.Println("error in parsing balance json : ", err, string(body))
-		return []CoinBalance{}
+		s.c.logger.Error("error in parsing balance json", "err", err, "data", string(body))
+		return nil, err
 	}
 
 	s.balances = make([]CoinBalance, 0)
@@ -45,15 +45,15 @@ func (s *AccountService) getBalances() []CoinBalance {
 		balance := CoinBalance{
 			AccountAlias:       obj.AccountAlias,
 			Asset:              obj.Asset,
-			Balance:            parseFloat(obj.Balance),
-			CrossWalletBalance: parseFloat(obj.CrossWalletBalance),
-			CrossUnPnl:         parseFloat(obj.CrossUnPnl),
-			AvailableBalance:   parseFloat(obj.AvailableBalance),
-			MaxWithdrawAmount:  parseFloat(obj.MaxWithdrawAmount),
+			Balance:            parseDecimal(obj.Balance),
+			CrossWalletBalance: parseDecimal(obj.CrossWalletBalance),
+			CrossUnPnl:         parseDecimal(obj.CrossUnPnl),
+			AvailableBalance:   parseDecimal(obj.AvailableBalance),
+			MaxWithdrawAmount:  parseDecimal(obj.MaxWithdrawAmount),
 			MarginAvailable:    obj.MarginAvailable,
 			UpdateTime:         obj.UpdateTime,
 		}
 		s.balances = append(s.balances, balance)
 	}
-	return s.balances
+	return s.balances, nil
 }
@@ -0,0 +1,217 @@
+package binance
+
+/* place, modify and cancel up to 5 orders in a single request */
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+const (
+	maxBatchOrders       = 5
+	maxBatchCancelOrders = 10
+)
+
+// ErrInvalidBatchSize is returned when a batch is empty or above the limit of its endpoint
+var ErrInvalidBatchSize = errors.New("invalid batch size")
+
+// BatchOrderResult is the outcome of a single order of a batch. Err is an
+// *OrderValidationError when the order was rejected locally, and an
+// *APIError when it was rejected by binance
+type BatchOrderResult struct {
+	Order *OrderResponse
+	Err   error
+}
+
+func checkBatchSize(n, max int) error {
+	if n == 0 || n > max {
+		return fmt.Errorf("%w : %d orders, the limit is %d", ErrInvalidBatchSize, n, max)
+	}
+	return nil
+}
+
+func (s *AccountService) PlaceBatchOrders(orders ...Order) ([]BatchOrderResult, error) {
+	return s.PlaceBatchOrdersContext(context.Background(), orders...)
+}
+
+// PlaceBatchOrdersContext places up to 5 orders in a single request, e.g. an
+// entry with its stop and take profit. the results are in the order of orders,
+// orders failing the symbol filters are not sent
+func (s *AccountService) PlaceBatchOrdersContext(ctx context.Context, orders ...Order) ([]BatchOrderResult, error) {
+	if err := checkBatchSize(len(orders), maxBatchOrders); err != nil {
+		return nil, err
+	}
+
+	results := make([]BatchOrderResult, len(orders))
+	batch := make([]map[string]interface{}, 0, len(orders))
+	for i, order := range orders {
+		orderService, err := s.newOrderService(ctx, nil, order)
+		if errors.Is(err, ErrInvalidOrder) {
+			results[i].Err = err
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		batch = append(batch, orderService.params())
+	}
+	return s.sendBatch(ctx, http.MethodPost, results, batch)
+}
+
+func (s *AccountService) ModifyBatchOrders(orders ...*ModifyOrderRequest) ([]BatchOrderResult, error) {
+	return s.ModifyBatchOrdersContext(context.Background(), orders...)
+}
+
+// ModifyBatchOrdersContext changes the price and quantity of up to 5 open limit orders
+func (s *AccountService) ModifyBatchOrdersContext(ctx context.Context, orders ...*ModifyOrderRequest) ([]BatchOrderResult, error) {
+	if err := checkBatchSize(len(orders), maxBatchOrders); err != nil {
+		return nil, err
+	}
+
+	results := make([]BatchOrderResult, len(orders))
+	batch := make([]map[string]interface{}, 0, len(orders))
+	for i, order := range orders {
+		params, err := s.modifyParams(ctx, order)
+		if errors.Is(err, ErrInvalidOrder) {
+			results[i].Err = err
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		batch = append(batch, params)
+	}
+	return s.sendBatch(ctx, http.MethodPut, results, batch)
+}
+
+// sendBatch sends the orders that passed validation and fills the empty
+// slots of results with the responses, in order
+func (s *AccountService) sendBatch(ctx context.Context, method string, results []BatchOrderResult, batch []map[string]interface{}) ([]BatchOrderResult, error) {
+	if len(batch) == 0 {
+		return results, nil
+	}
+
+	// binance expects every value of the batch as a string
+	list := make([]map[string]string, 0, len(batch))
+	for _, params := range batch {
+		m := make(map[string]string, len(params))
+		for k, v := range params {
+			m[k] = fmt.Sprintf("%v", v)
+		}
+		list = append(list, m)
+	}
+	batchOrders, err := json.Marshal(list)
+	if err != nil {
+		return nil, err
+	}
+
+	req := request{
+		method:   method,
+		endpoint: endPointBatchOrders,
+		secType:  secTypeSigned,
+		orders:   int64(len(batch)),
+	}
+	req.setParam(key_BATCH_ORDERS, string(batchOrders))
+	data, err := s.c.callAPI(ctx, &req)
+	if err != nil {
+		s.c.logger.Error("error in sending batch orders", "err", err, "method", method, "orders", len(batch))
+		return nil, err
+	}
+
+	responses, err := s.parseBatchResponse(data)
+	if err != nil {
+		return nil, err
+	}
+	i := 0
+	for _, res := range responses {
+		for i < len(results) && results[i].Err != nil {
+			i++
+		}
+		if i == len(results) {
+			break
+		}
+		results[i] = res
+		i++
+	}
+	return results, nil
+}
+
+func (s *AccountService) CancelBatchOrders(symbol string, orderIds []int64) ([]BatchOrderResult, error) {
+	return s.CancelBatchOrdersContext(context.Background(), symbol, orderIds)
+}
+
+// CancelBatchOrdersContext cancels up to 10 orders of a symbol by order id
+func (s *AccountService) CancelBatchOrdersContext(ctx context.Context, symbol string, orderIds []int64) ([]BatchOrderResult, error) {
+	if err := checkBatchSize(len(orderIds), maxBatchCancelOrders); err != nil {
+		return nil, err
+	}
+	orderIdList, err := json.Marshal(orderIds)
+	if err != nil {
+		return nil, err
+	}
+	return s.cancelBatch(ctx, symbol, key_ORDER_ID_LIST, string(orderIdList))
+}
+
+func (s *AccountService) CancelBatchOrdersByClientOrderId(symbol string, clientOrderIds []string) ([]BatchOrderResult, error) {
+	return s.CancelBatchOrdersByClientOrderIdContext(context.Background(), symbol, clientOrderIds)
+}
+
+// CancelBatchOrdersByClientOrderIdContext cancels up to 10 orders of a symbol by client order id
+func (s *AccountService) CancelBatchOrdersByClientOrderIdContext(ctx context.Context, symbol string, clientOrderIds []string) ([]BatchOrderResult, error) {
+	if err := checkBatchSize(len(clientOrderIds), maxBatchCancelOrders); err != nil {
+		return nil, err
+	}
+	clientOrderIdList, err := json.Marshal(clientOrderIds)
+	if err != nil {
+		return nil, err
+	}
+	return s.cancelBatch(ctx, symbol, key_ORIG_CLIENT_ORDER_ID_LIST, string(clientOrderIdList))
+}
+
+func (s *AccountService) cancelBatch(ctx context.Context, symbol, key, list string) ([]BatchOrderResult, error) {
+	req := request{
+		method:   http.MethodDelete,
+		endpoint: endPointBatchOrders,
+		secType:  secTypeSigned,
+		weight:   1,
+	}
+	req.setParam(key_SYMBOL, symbol)
+	req.setParam(key, list)
+	data, err := s.c.callAPI(ctx, &req)
+	if err != nil {
+		s.c.logger.Error("error in cancelling batch orders", "err", err, "symbol", symbol)
+		return nil, err
+	}
+	return s.parseBatchResponse(data)
+}
+
+// parseBatchResponse parses a list mixing orders and errors, one per order of the batch
+func (s *AccountService) parseBatchResponse(data []byte) ([]BatchOrderResult, error) {
+	var resList []json.RawMessage
+	err := json.Unmarshal(data, &resList)
+	if err != nil {
+		s.c.logger.Error("error in parsing batch orders response", "err", err, "data", string(data))
+		return nil, err
+	}
+
+	results := make([]BatchOrderResult, 0, len(resList))
+	for _, raw := range resList {
+		var e jsonAPIError
+		if err := json.Unmarshal(raw, &e); err == nil && e.Code != 0 {
+			results = append(results, BatchOrderResult{
+				Err: &APIError{Code: e.Code, Message: e.Message, Endpoint: endPointBatchOrders},
+			})
+			continue
+		}
+		var res jsonOrderResponse
+		if err := json.Unmarshal(raw, &res); err != nil {
+			s.c.logger.Error("error in parsing batch order", "err", err, "data", string(raw))
+			results = append(results, BatchOrderResult{Err: err})
+			continue
+		}
+		results = append(results, BatchOrderResult{Order: res.toOrderResponse()})
+	}
+	return results, nil
+}
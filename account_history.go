@@ -0,0 +1,127 @@
+package binance
+
+/* paging shared by the order and trade history iterators */
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	maxHistoryWindow = 7 * 24 * time.Hour
+	maxHistoryLimit  = 1000
+)
+
+// ErrInvalidTimeRange is returned by the history iterators for a missing
+// start time or an end time before the start time
+var ErrInvalidTimeRange = errors.New("invalid time range")
+
+// checkTimeRange requires a start time, walking from 1970 would take
+// thousands of empty window requests
+func checkTimeRange(startTime, endTime int64) error {
+	if startTime <= 0 {
+		return fmt.Errorf("%w : start time is required", ErrInvalidTimeRange)
+	}
+	if endTime < startTime {
+		return fmt.Errorf("%w : end time %d is before start time %d", ErrInvalidTimeRange, endTime, startTime)
+	}
+	return nil
+}
+
+// historyKey is the id and time of a fetched order or trade
+type historyKey struct {
+	Id   int64
+	Time int64
+}
+
+// historyFetch fetches one page, either the time window from startTime to
+// endTime or the page starting at fromId when fromId is set
+type historyFetch func(startTime, endTime, fromId int64) ([]historyKey, error)
+
+// historyPager walks a time range in 7 day windows until it finds records,
+// then follows their ids so pages of 1000 records are never cut short
+type historyPager struct {
+	startTime int64
+	endTime   int64
+	nextId    int64 // next id once the first record is found
+	err       error
+	done      bool
+}
+
+func newHistoryPager(startTime, endTime int64) historyPager {
+	if endTime == 0 {
+		endTime = CurrentTimestamp()
+	}
+	if err := checkTimeRange(startTime, endTime); err != nil {
+		return historyPager{err: err, done: true}
+	}
+	return historyPager{startTime: startTime, endTime: endTime}
+}
+
+// next fetches pages until one is not empty and returns how many of its
+// leading records are within the range, false when done or on error
+func (p *historyPager) next(fetch historyFetch) (int, bool) {
+	for !p.done {
+		var startTime, endTime int64
+		if p.nextId == 0 {
+			if p.startTime > p.endTime {
+				p.done = true
+				break
+			}
+			startTime = p.startTime
+			endTime = windowEnd(p.startTime, p.endTime, maxHistoryWindow)
+		}
+
+		keys, err := fetch(startTime, endTime, p.nextId)
+		if err != nil {
+			p.err = err
+			p.done = true
+			return 0, false
+		}
+
+		if p.nextId == 0 {
+			if len(keys) < maxHistoryLimit {
+				p.startTime = endTime + 1
+			} else {
+				p.nextId = keys[len(keys)-1].Id + 1
+			}
+		} else {
+			if len(keys) < maxHistoryLimit {
+				p.done = true
+			}
+			keys = keysUntil(keys, p.endTime)
+			if len(keys) == 0 {
+				p.done = true
+				break
+			}
+			if keys[len(keys)-1].Time >= p.endTime {
+				p.done = true
+			}
+			p.nextId = keys[len(keys)-1].Id + 1
+		}
+
+		if len(keys) > 0 {
+			return len(keys), true
+		}
+	}
+	return 0, false
+}
+
+// keysUntil drops the records after endTime
+func keysUntil(keys []historyKey, endTime int64) []historyKey {
+	for i, key := range keys {
+		if key.Time > endTime {
+			return keys[:i]
+		}
+	}
+	return keys
+}
+
+// windowEnd returns the end of the query window starting at start, capped at end
+func windowEnd(start, end int64, window time.Duration) int64 {
+	windowEnd := start + window.Milliseconds() - 1
+	if windowEnd > end {
+		return end
+	}
+	return windowEnd
+}
@@ -0,0 +1,46 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestHistoryTimeRange(t *testing.T) {
+	tests := []struct {
+		name      string
+		startTime int64
+		endTime   int64
+		wantErr   bool
+	}{
+		{"zero start", 0, 1700000000000, true},
+		{"zero start and end", 0, 0, true},
+		{"end before start", 1700000000000, 1600000000000, true},
+		{"start and end", 1600000000000, 1700000000000, false},
+		{"end is now", 1600000000000, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			p := newHistoryPager(tt.startTime, tt.endTime)
+			p.next(func(startTime, endTime, fromId int64) ([]historyKey, error) {
+				calls++
+				return nil, nil
+			})
+			it := (&AccountService{}).NewIncomeIterator("", "", tt.startTime, tt.endTime)
+
+			if !tt.wantErr {
+				if p.err != nil || calls == 0 {
+					t.Errorf("pager err = %v after %d calls", p.err, calls)
+				}
+				return
+			}
+			if !errors.Is(p.err, ErrInvalidTimeRange) || calls != 0 {
+				t.Errorf("pager err = %v after %d calls, want ErrInvalidTimeRange", p.err, calls)
+			}
+			if it.Next(context.Background()) || !errors.Is(it.Err(), ErrInvalidTimeRange) {
+				t.Errorf("income iterator err = %v, want ErrInvalidTimeRange", it.Err())
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package binance
+
+/* income history, realized pnl, funding fees, commissions and transfers */
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+type jsonIncome struct {
+	Symbol     string     `json:"symbol"`
+	IncomeType IncomeType `json:"incomeType"`
+	Income     string     `json:"income"`
+	Asset      string     `json:"asset"`
+	Info       string     `json:"info"`
+	Time       int64      `json:"time"`
+	TranId     int64      `json:"tranId"`
+	TradeId    string     `json:"tradeId"`
+}
+
+// IncomeQuery selects a single page of income history, all symbols and
+// income types are returned when Symbol and IncomeType are empty
+type IncomeQuery struct {
+	Symbol     string
+	IncomeType IncomeType
+	StartTime  int64
+	EndTime    int64
+	Limit      int // default 100, max 1000
+}
+
+func (s *AccountService) GetIncome(query *IncomeQuery) ([]*Income, error) {
+	return s.GetIncomeContext(context.Background(), query)
+}
+
+func (s *AccountService) GetIncomeContext(ctx context.Context, query *IncomeQuery) ([]*Income, error) {
+	req := request{
+		method:   http.MethodGet,
+		endpoint: endPointIncome,
+		secType:  secTypeSigned,
+	}
+	if query.Symbol != "" {
+		req.setParam(key_SYMBOL, query.Symbol)
+	}
+	if query.IncomeType != "" {
+		req.setParam(key_INCOME_TYPE, query.IncomeType)
+	}
+	if query.StartTime > 0 {
+		req.setParam(key_STARTTIME, query.StartTime)
+	}
+	if query.EndTime > 0 {
+		req.setParam(key_ENDTIME, query.EndTime)
+	}
+	if query.Limit > 0 {
+		req.setParam(key_LIMIT, query.Limit)
+	}
+
+	data, err := s.c.callAPI(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	var resList []jsonIncome
+	err = json.Unmarshal(data, &resList)
+	if err != nil {
+		s.c.logger.Error("error in parsing income json", "err", err, "data", string(data))
+		return nil, err
+	}
+	incomes := make([]*Income, 0, len(resList))
+	for _, res := range resList {
+		incomes = append(incomes, &Income{
+			Symbol:     res.Symbol,
+			IncomeType: res.IncomeType,
+			Income:     parseDecimal(res.Income),
+			Asset:      res.Asset,
+			Info:       res.Info,
+			TranId:     res.TranId,
+			TradeId:    res.TradeId,
+			Time:       res.Time,
+		})
+	}
+	return incomes, nil
+}
+
+// ErrIncompleteHistory is returned by the income iterator when binance has
+// more entries in one millisecond than fit in a page
+var ErrIncompleteHistory = errors.New("incomplete income history")
+
+// incomeKey identifies an income entry, a trade has a realized pnl and a
+// commission entry sharing the same tranId
+type incomeKey struct {
+	tranId     int64
+	incomeType IncomeType
+	asset      string
+}
+
+// IncomeIterator pages through the income history between two times. it
+// walks the range in 7 day windows, a full page moves the window start to
+// the time of its last entry and entries already returned are skipped. more
+// than 1000 entries in one millisecond fail with ErrIncompleteHistory.
+type IncomeIterator struct {
+	s         *AccountService
+	query     IncomeQuery
+	startTime int64
+	endTime   int64
+	seen      map[incomeKey]bool // entries at startTime already returned
+	page      []*Income
+	err       error
+	done      bool
+}
+
+// NewIncomeIterator iterates income between startTime and endTime
+// (milliseconds), endTime zero means now. symbol and incomeType may be empty,
+// a zero startTime or an endTime before it fails with ErrInvalidTimeRange
+func (s *AccountService) NewIncomeIterator(symbol string, incomeType IncomeType, startTime, endTime int64) *IncomeIterator {
+	if endTime == 0 {
+		endTime = CurrentTimestamp()
+	}
+	if err := checkTimeRange(startTime, endTime); err != nil {
+		return &IncomeIterator{err: err, done: true}
+	}
+	return &IncomeIterator{
+		s:         s,
+		query:     IncomeQuery{Symbol: symbol, IncomeType: incomeType, Limit: maxHistoryLimit},
+		startTime: startTime,
+		endTime:   endTime,
+		seen:      make(map[incomeKey]bool),
+	}
+}
+
+// Next fetches the next non empty page, it returns false when done or on error
+func (it *IncomeIterator) Next(ctx context.Context) bool {
+	for !it.done && it.startTime <= it.endTime {
+		query := it.query
+		query.StartTime = it.startTime
+		query.EndTime = windowEnd(it.startTime, it.endTime, maxHistoryWindow)
+
+		incomes, err := it.s.GetIncomeContext(ctx, &query)
+		if err != nil {
+			it.err = err
+			it.done = true
+			return false
+		}
+
+		page := make([]*Income, 0, len(incomes))
+		for _, income := range incomes {
+			key := incomeKey{income.TranId, income.IncomeType, income.Asset}
+			if income.Time == it.startTime && it.seen[key] {
+				continue
+			}
+			page = append(page, income)
+		}
+
+		if len(incomes) < maxHistoryLimit {
+			it.startTime = query.EndTime + 1
+			it.seen = make(map[incomeKey]bool)
+		} else if len(page) == 0 {
+			// a full page of entries at the same time, the entries after them
+			// in that millisecond can not be fetched
+			it.err = fmt.Errorf("%w : more than %d income entries at %d", ErrIncompleteHistory, maxHistoryLimit, it.startTime)
+			it.done = true
+			it.page = nil
+			return false
+		} else {
+			last := incomes[len(incomes)-1].Time
+			if last != it.startTime {
+				it.seen = make(map[incomeKey]bool)
+			}
+			it.startTime = last
+			for _, income := range incomes {
+				if income.Time == last {
+					it.seen[incomeKey{income.TranId, income.IncomeType, income.Asset}] = true
+				}
+			}
+		}
+
+		if len(page) > 0 {
+			it.page = page
+			return true
+		}
+	}
+	it.done = true
+	it.page = nil
+	return false
+}
+
+// Page returns the income entries fetched by the last call to Next
+func (it *IncomeIterator) Page() []*Income {
+	return it.page
+}
+
+func (it *IncomeIterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,40 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestIncomeIteratorFullMillisecond(t *testing.T) {
+	const at = 1700000000005
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		entries := make([]string, maxHistoryLimit)
+		for i := range entries {
+			entries[i] = fmt.Sprintf(`{"incomeType":"FUNDING_FEE","income":"-0.1","asset":"USDT","time":%d,"tranId":%d}`, at, i+1)
+		}
+		fmt.Fprintf(w, "[%s]", strings.Join(entries, ","))
+	}))
+	defer srv.Close()
+	s := NewClient("k", "s", WithBaseURL(srv.URL)).NewAccountService()
+
+	it := s.NewIncomeIterator("", IncomeTypeFundingFee, at-5, at+1000)
+	if !it.Next(context.Background()) || len(it.Page()) != maxHistoryLimit {
+		t.Fatalf("first page has %d entries, err %v", len(it.Page()), it.Err())
+	}
+	if it.Next(context.Background()) {
+		t.Fatalf("second page has %d entries, want none", len(it.Page()))
+	}
+	if !errors.Is(it.Err(), ErrIncompleteHistory) {
+		t.Errorf("err = %v, want ErrIncompleteHistory", it.Err())
+	}
+	if calls != 2 {
+		t.Errorf("income fetched %d times, want 2", calls)
+	}
+}
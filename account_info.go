@@ -0,0 +1,233 @@
+package binance
+
+/* account snapshot and position risk */
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+type jsonAccountAsset struct {
+	Asset                  string `json:"asset"`
+	WalletBalance          string `json:"walletBalance"`
+	UnrealizedProfit       string `json:"unrealizedProfit"`
+	MarginBalance          string `json:"marginBalance"`
+	MaintMargin            string `json:"maintMargin"`
+	InitialMargin          string `json:"initialMargin"`
+	PositionInitialMargin  string `json:"positionInitialMargin"`
+	OpenOrderInitialMargin string `json:"openOrderInitialMargin"`
+	CrossWalletBalance     string `json:"crossWalletBalance"`
+	CrossUnPnl             string `json:"crossUnPnl"`
+	AvailableBalance       string `json:"availableBalance"`
+	MaxWithdrawAmount      string `json:"maxWithdrawAmount"`
+	MarginAvailable        bool   `json:"marginAvailable"`
+	UpdateTime             int64  `json:"updateTime"`
+}
+
+type jsonAccountPosition struct {
+	Symbol                 string           `json:"symbol"`
+	InitialMargin          string           `json:"initialMargin"`
+	MaintMargin            string           `json:"maintMargin"`
+	UnrealizedProfit       string           `json:"unrealizedProfit"`
+	PositionInitialMargin  string           `json:"positionInitialMargin"`
+	OpenOrderInitialMargin string           `json:"openOrderInitialMargin"`
+	Leverage               string           `json:"leverage"`
+	Isolated               bool             `json:"isolated"`
+	EntryPrice             string           `json:"entryPrice"`
+	BreakEvenPrice         string           `json:"breakEvenPrice"`
+	MaxNotional            string           `json:"maxNotional"`
+	BidNotional            string           `json:"bidNotional"`
+	AskNotional            string           `json:"askNotional"`
+	PositionSide           PositionSideType `json:"positionSide"`
+	PositionAmount         string           `json:"positionAmt"`
+	UpdateTime             int64            `json:"updateTime"`
+}
+
+type jsonAccount struct {
+	FeeTier                     int64                 `json:"feeTier"`
+	CanTrade                    bool                  `json:"canTrade"`
+	CanDeposit                  bool                  `json:"canDeposit"`
+	CanWithdraw                 bool                  `json:"canWithdraw"`
+	MultiAssetsMargin           bool                  `json:"multiAssetsMargin"`
+	UpdateTime                  int64                 `json:"updateTime"`
+	TotalInitialMargin          string                `json:"totalInitialMargin"`
+	TotalMaintMargin            string                `json:"totalMaintMargin"`
+	TotalWalletBalance          string                `json:"totalWalletBalance"`
+	TotalUnrealizedProfit       string                `json:"totalUnrealizedProfit"`
+	TotalMarginBalance          string                `json:"totalMarginBalance"`
+	TotalPositionInitialMargin  string                `json:"totalPositionInitialMargin"`
+	TotalOpenOrderInitialMargin string                `json:"totalOpenOrderInitialMargin"`
+	TotalCrossWalletBalance     string                `json:"totalCrossWalletBalance"`
+	TotalCrossUnPnl             string                `json:"totalCrossUnPnl"`
+	AvailableBalance            string                `json:"availableBalance"`
+	MaxWithdrawAmount           string                `json:"maxWithdrawAmount"`
+	Assets                      []jsonAccountAsset    `json:"assets"`
+	Positions                   []jsonAccountPosition `json:"positions"`
+}
+
+func (s *AccountService) GetAccount() (*Account, error) {
+	return s.GetAccountContext(context.Background())
+}
+
+// GetAccountContext returns the account totals, the balance of every asset and every position
+func (s *AccountService) GetAccountContext(ctx context.Context) (*Account, error) {
+	req := request{
+		method:   http.MethodGet,
+		endpoint: endPointAccount,
+		secType:  secTypeSigned,
+	}
+
+	data, err := s.c.callAPI(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	var res jsonAccount
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		s.c.logger.Error("error in parsing account json", "err", err, "data", string(data))
+		return nil, err
+	}
+
+	account := &Account{
+		FeeTier:                     res.FeeTier,
+		CanTrade:                    res.CanTrade,
+		CanDeposit:                  res.CanDeposit,
+		CanWithdraw:                 res.CanWithdraw,
+		MultiAssetsMargin:           res.MultiAssetsMargin,
+		TotalInitialMargin:          parseDecimal(res.TotalInitialMargin),
+		TotalMaintMargin:            parseDecimal(res.TotalMaintMargin),
+		TotalWalletBalance:          parseDecimal(res.TotalWalletBalance),
+		TotalUnrealizedProfit:       parseDecimal(res.TotalUnrealizedProfit),
+		TotalMarginBalance:          parseDecimal(res.TotalMarginBalance),
+		TotalPositionInitialMargin:  parseDecimal(res.TotalPositionInitialMargin),
+		TotalOpenOrderInitialMargin: parseDecimal(res.TotalOpenOrderInitialMargin),
+		TotalCrossWalletBalance:     parseDecimal(res.TotalCrossWalletBalance),
+		TotalCrossUnPnl:             parseDecimal(res.TotalCrossUnPnl),
+		AvailableBalance:            parseDecimal(res.AvailableBalance),
+		MaxWithdrawAmount:           parseDecimal(res.MaxWithdrawAmount),
+		Assets:                      make([]*AccountAsset, 0, len(res.Assets)),
+		Positions:                   make([]*AccountPosition, 0, len(res.Positions)),
+		UpdateTime:                  res.UpdateTime,
+	}
+	for _, obj := range res.Assets {
+		account.Assets = append(account.Assets, &AccountAsset{
+			Asset:                  obj.Asset,
+			WalletBalance:          parseDecimal(obj.WalletBalance),
+			UnrealizedProfit:       parseDecimal(obj.UnrealizedProfit),
+			MarginBalance:          parseDecimal(obj.MarginBalance),
+			MaintMargin:            parseDecimal(obj.MaintMargin),
+			InitialMargin:          parseDecimal(obj.InitialMargin),
+			PositionInitialMargin:  parseDecimal(obj.PositionInitialMargin),
+			OpenOrderInitialMargin: parseDecimal(obj.OpenOrderInitialMargin),
+			CrossWalletBalance:     parseDecimal(obj.CrossWalletBalance),
+			CrossUnPnl:             parseDecimal(obj.CrossUnPnl),
+			AvailableBalance:       parseDecimal(obj.AvailableBalance),
+			MaxWithdrawAmount:      parseDecimal(obj.MaxWithdrawAmount),
+			MarginAvailable:        obj.MarginAvailable,
+			UpdateTime:             obj.UpdateTime,
+		})
+	}
+	for _, obj := range res.Positions {
+		account.Positions = append(account.Positions, &AccountPosition{
+			Symbol:                 obj.Symbol,
+			PositionSide:           obj.PositionSide,
+			PositionAmount:         parseDecimal(obj.PositionAmount),
+			EntryPrice:             parseDecimal(obj.EntryPrice),
+			BreakEvenPrice:         parseDecimal(obj.BreakEvenPrice),
+			UnrealizedProfit:       parseDecimal(obj.UnrealizedProfit),
+			InitialMargin:          parseDecimal(obj.InitialMargin),
+			MaintMargin:            parseDecimal(obj.MaintMargin),
+			PositionInitialMargin:  parseDecimal(obj.PositionInitialMargin),
+			OpenOrderInitialMargin: parseDecimal(obj.OpenOrderInitialMargin),
+			Leverage:               ParseInt(obj.Leverage),
+			Isolated:               obj.Isolated,
+			MaxNotional:            parseDecimal(obj.MaxNotional),
+			BidNotional:            parseDecimal(obj.BidNotional),
+			AskNotional:            parseDecimal(obj.AskNotional),
+			UpdateTime:             obj.UpdateTime,
+		})
+	}
+	return account, nil
+}
+
+type jsonPosition struct {
+	Symbol           string           `json:"symbol"`
+	PositionSide     PositionSideType `json:"positionSide"`
+	PositionAmount   string           `json:"positionAmt"`
+	EntryPrice       string           `json:"entryPrice"`
+	BreakEvenPrice   string           `json:"breakEvenPrice"`
+	MarkPrice        string           `json:"markPrice"`
+	LiquidationPrice string           `json:"liquidationPrice"`
+	UnrealizedProfit string           `json:"unRealizedProfit"`
+	Leverage         string           `json:"leverage"`
+	MaxNotionalValue string           `json:"maxNotionalValue"`
+	MarginType       string           `json:"marginType"`
+	IsolatedMargin   string           `json:"isolatedMargin"`
+	IsolatedWallet   string           `json:"isolatedWallet"`
+	IsAutoAddMargin  string           `json:"isAutoAddMargin"`
+	Notional         string           `json:"notional"`
+	UpdateTime       int64            `json:"updateTime"`
+}
+
+func (s *AccountService) GetPositions(symbol string) ([]*Position, error) {
+	return s.GetPositionsContext(context.Background(), symbol)
+}
+
+// GetPositionsContext returns the position risk of a symbol, or of every symbol when symbol is empty
+func (s *AccountService) GetPositionsContext(ctx context.Context, symbol string) ([]*Position, error) {
+	req := request{
+		method:   http.MethodGet,
+		endpoint: endPointPositionRisk,
+		secType:  secTypeSigned,
+	}
+	if symbol != "" {
+		req.setParam(key_SYMBOL, symbol)
+	}
+
+	data, err := s.c.callAPI(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	var resList []jsonPosition
+	err = json.Unmarshal(data, &resList)
+	if err != nil {
+		s.c.logger.Error("error in parsing position risk json", "err", err, "data", string(data))
+		return nil, err
+	}
+	positions := make([]*Position, 0, len(resList))
+	for _, res := range resList {
+		positions = append(positions, &Position{
+			Symbol:           res.Symbol,
+			PositionSide:     res.PositionSide,
+			PositionAmount:   parseDecimal(res.PositionAmount),
+			EntryPrice:       parseDecimal(res.EntryPrice),
+			BreakEvenPrice:   parseDecimal(res.BreakEvenPrice),
+			MarkPrice:        parseDecimal(res.MarkPrice),
+			LiquidationPrice: parseDecimal(res.LiquidationPrice),
+			UnrealizedProfit: parseDecimal(res.UnrealizedProfit),
+			Leverage:         ParseInt(res.Leverage),
+			MaxNotionalValue: parseDecimal(res.MaxNotionalValue),
+			MarginType:       parseMarginType(res.MarginType),
+			IsolatedMargin:   parseDecimal(res.IsolatedMargin),
+			IsolatedWallet:   parseDecimal(res.IsolatedWallet),
+			IsAutoAddMargin:  res.IsAutoAddMargin == "true",
+			Notional:         parseDecimal(res.Notional),
+			UpdateTime:       res.UpdateTime,
+		})
+	}
+	return positions, nil
+}
+
+// parseMarginType converts the lower case margin types of position risk ("cross", "isolated")
+func parseMarginType(marginType string) MarginType {
+	switch strings.ToLower(marginType) {
+	case "cross", "crossed":
+		return MarginTypeCrossed
+	case "isolated":
+		return MarginTypeIsolated
+	}
+	return MarginType(marginType)
+}
@@ -1,8 +1,11 @@
 package binance
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
-	"log"
+	"errors"
+	"fmt"
 	"net/http"
 )
 
@@ -12,7 +15,7 @@ type jsonLeverage struct {
 	Symbol         string `json:"symbol"`
 }
 
-func (s *AccountService) updateLeverage(symbol string, leverage int) bool {
+func (s *AccountService) updateLeverage(ctx context.Context, symbol string, leverage int) (*LeverageResult, error) {
 	req := request{
 		method:   http.MethodPost,
 		endpoint: endPointLeverage,
@@ -21,16 +24,194 @@ func (s *AccountService) updateLeverage(symbol string, leverage int) bool {
 	req.setParam(key_SYMBOL, symbol)
 	req.setParam(key_LEVERAGE, leverage)
 
-	data, err := s.c.callAPI(&req)
+	data, err := s.c.callAPI(ctx, &req)
 	if err != nil {
-		return false
+		return nil, err
 	}
 
 	var res jsonLeverage
 	err = json.Unmarshal(data, &res)
 	if err != nil {
-		log.Println("error in parsing leverage json : ", err, string(data))
-		return false
+		s.c.logger.Error("error in parsing leverage json", "err", err, "data", string(data))
+		return nil, err
 	}
-	return res.Leverage == leverage
+	return &LeverageResult{
+		Symbol:           res.Symbol,
+		Leverage:         res.Leverage,
+		MaxNotionalValue: parseDecimal(res.MaxNotionValue),
+	}, nil
+}
+
+// ErrLeverageTooHigh is returned when a leverage is above the bracket of the notional
+var ErrLeverageTooHigh = errors.New("leverage above the bracket of the notional")
+
+type jsonLeverageBracket struct {
+	Bracket          int     `json:"bracket"`
+	InitialLeverage  int     `json:"initialLeverage"`
+	NotionalCap      Decimal `json:"notionalCap"`
+	NotionalFloor    Decimal `json:"notionalFloor"`
+	MaintMarginRatio Decimal `json:"maintMarginRatio"`
+	Cum              Decimal `json:"cum"`
+}
+
+type jsonSymbolLeverageBrackets struct {
+	Symbol       string                `json:"symbol"`
+	NotionalCoef Decimal               `json:"notionalCoef"`
+	Brackets     []jsonLeverageBracket `json:"brackets"`
+}
+
+func (s *AccountService) GetLeverageBrackets(symbol string) ([]*SymbolLeverageBrackets, error) {
+	return s.GetLeverageBracketsContext(context.Background(), symbol)
+}
+
+// GetLeverageBracketsContext returns the notional brackets of a symbol, or of
+// every symbol when symbol is empty
+func (s *AccountService) GetLeverageBracketsContext(ctx context.Context, symbol string) ([]*SymbolLeverageBrackets, error) {
+	req := request{
+		method:   http.MethodGet,
+		endpoint: endPointLeverageBracket,
+		secType:  secTypeSigned,
+	}
+	if symbol != "" {
+		req.setParam(key_SYMBOL, symbol)
+	}
+
+	data, err := s.c.callAPI(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	// a single object when a symbol is sent, a list otherwise
+	var resList []jsonSymbolLeverageBrackets
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		resList = make([]jsonSymbolLeverageBrackets, 1)
+		err = json.Unmarshal(data, &resList[0])
+	} else {
+		err = json.Unmarshal(data, &resList)
+	}
+	if err != nil {
+		s.c.logger.Error("error in parsing leverage bracket json", "err", err, "data", string(data))
+		return nil, err
+	}
+
+	symbols := make([]*SymbolLeverageBrackets, 0, len(resList))
+	for _, res := range resList {
+		brackets := &SymbolLeverageBrackets{
+			Symbol:       res.Symbol,
+			NotionalCoef: res.NotionalCoef,
+			Brackets:     make([]LeverageBracket, 0, len(res.Brackets)),
+		}
+		for _, b := range res.Brackets {
+			brackets.Brackets = append(brackets.Brackets, LeverageBracket{
+				Bracket:          b.Bracket,
+				InitialLeverage:  b.InitialLeverage,
+				NotionalFloor:    b.NotionalFloor,
+				NotionalCap:      b.NotionalCap,
+				MaintMarginRatio: b.MaintMarginRatio,
+				Cum:              b.Cum,
+			})
+		}
+		symbols = append(symbols, brackets)
+	}
+	return symbols, nil
+}
+
+// Bracket returns the bracket of a notional, the sign of notional is ignored.
+// notionals above the last cap return nil
+func (b *SymbolLeverageBrackets) Bracket(notional Decimal) *LeverageBracket {
+	notional = notional.Abs()
+	for i := range b.Brackets {
+		bracket := &b.Brackets[i]
+		if notional.LessThan(bracket.NotionalFloor) {
+			continue
+		}
+		if bracket.NotionalCap.IsZero() || notional.LessThan(bracket.NotionalCap) {
+			return bracket
+		}
+	}
+	return nil
+}
+
+// Plan returns the max leverage and maintenance margin of a notional
+func (b *SymbolLeverageBrackets) Plan(notional Decimal) (*LeveragePlan, error) {
+	bracket := b.Bracket(notional)
+	if bracket == nil {
+		return nil, fmt.Errorf("%w : notional %s of %s is above the last bracket", ErrLeverageTooHigh, notional, b.Symbol)
+	}
+	notional = notional.Abs()
+	return &LeveragePlan{
+		Symbol:           b.Symbol,
+		Notional:         notional,
+		MaxLeverage:      bracket.InitialLeverage,
+		MaintMarginRatio: bracket.MaintMarginRatio,
+		MaintAmount:      bracket.Cum,
+		MaintMargin:      notional.Mul(bracket.MaintMarginRatio).Sub(bracket.Cum),
+		Bracket:          bracket.Bracket,
+		NotionalCap:      bracket.NotionalCap,
+	}, nil
+}
+
+func (s *AccountService) PlanLeverage(symbol string, notional Decimal) (*LeveragePlan, error) {
+	return s.PlanLeverageContext(context.Background(), symbol, notional)
+}
+
+// PlanLeverageContext returns the max leverage, maintenance margin ratio and
+// maintenance amount for a position of the given notional
+func (s *AccountService) PlanLeverageContext(ctx context.Context, symbol string, notional Decimal) (*LeveragePlan, error) {
+	brackets, err := s.symbolLeverageBrackets(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+	return brackets.Plan(notional)
+}
+
+func (s *AccountService) symbolLeverageBrackets(ctx context.Context, symbol string) (*SymbolLeverageBrackets, error) {
+	symbols, err := s.GetLeverageBracketsContext(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+	for _, brackets := range symbols {
+		if brackets.Symbol == symbol {
+			return brackets, nil
+		}
+	}
+	return nil, fmt.Errorf("no leverage brackets for %s", symbol)
+}
+
+func (s *AccountService) SetLeverageChecked(symbol string, leverage int) (*LeverageResult, error) {
+	return s.SetLeverageCheckedContext(context.Background(), symbol, leverage)
+}
+
+// SetLeverageCheckedContext sets the leverage only if the open positions of
+// the symbol stay within the bracket of that leverage, otherwise it returns
+// an error matching ErrLeverageTooHigh without changing anything
+func (s *AccountService) SetLeverageCheckedContext(ctx context.Context, symbol string, leverage int) (*LeverageResult, error) {
+	brackets, err := s.symbolLeverageBrackets(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+	positions, err := s.GetPositionsContext(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range positions {
+		if p.Symbol != symbol || p.PositionAmount.IsZero() {
+			continue
+		}
+		notional := p.Notional
+		if notional.IsZero() {
+			notional = p.PositionAmount.Mul(p.MarkPrice)
+		}
+		plan, err := brackets.Plan(notional)
+		if err != nil {
+			return nil, err
+		}
+		if leverage > plan.MaxLeverage {
+			return nil, fmt.Errorf("%w : %s %s position notional %s allows up to %dx, not %dx",
+				ErrLeverageTooHigh, symbol, p.Key().PositionSide, plan.Notional, plan.MaxLeverage, leverage)
+		}
+	}
+	return s.updateLeverage(ctx, symbol, leverage)
 }
@@ -1,8 +1,8 @@
 package binance
 
 import (
+	"context"
 	"encoding/json"
-	"log"
 	"net/http"
 )
 
@@ -10,22 +10,22 @@ type jsonListenKey struct {
 	ListenKey string `json:"listenKey"`
 }
 
-func (s *AccountStream) getListenKey() string {
+func (s *AccountStream) getListenKey(ctx context.Context) (string, error) {
 	req := request{
 		method:   http.MethodPost,
 		endpoint: endPointListenKey,
 		secType:  secTypeSigned,
 	}
-	data, err := s.c.callAPI(&req)
+	data, err := s.c.callAPI(ctx, &req)
 	if err != nil {
-		return ""
+		return "", err
 	}
 
 	var res jsonListenKey
 	err = json.Unmarshal(data, &res)
 	if err != nil {
-		log.Println("error in parsing listen key : ", err, string(data))
-		return ""
+		s.c.logger.Error("error in parsing listen key", "err", err)
+		return "", err
 	}
-	return res.ListenKey
+	return res.ListenKey, nil
 }
@@ -1,17 +1,14 @@
 package binance
 
 import (
+	"context"
 	"encoding/json"
-	"log"
+	"errors"
 	"net/http"
+	"strconv"
 )
 
-type jsonMarginTypeResponse struct {
-	Code    int    `json:"code"`
-	Message string `json:"msg"`
-}
-
-func (s *AccountService) updateMarginType(symbol string, marginType MarginType) bool {
+func (s *AccountService) updateMarginType(ctx context.Context, symbol string, marginType MarginType) (*MarginTypeResult, error) {
 	req := request{
 		method:   http.MethodPost,
 		endpoint: endPointMarginType,
@@ -20,16 +17,177 @@ func (s *AccountService) updateMarginType(symbol string, marginType MarginType)
 	req.setParam(key_SYMBOL, symbol)
 	req.setParam(key_MARGIN_TYPE, marginType)
 
-	data, err := s.c.callAPI(&req)
+	_, err := s.c.callAPI(ctx, &req)
+	if errors.Is(err, ErrNoNeedToChangeMarginType) {
+		return &MarginTypeResult{Symbol: symbol, MarginType: marginType}, nil
+	}
 	if err != nil {
-		return false
+		return nil, err
 	}
+	return &MarginTypeResult{Symbol: symbol, MarginType: marginType, Changed: true}, nil
+}
 
-	var res jsonMarginTypeResponse
+type jsonMultiAssetsMode struct {
+	MultiAssetsMargin bool `json:"multiAssetsMargin"`
+}
+
+func (s *AccountService) GetMultiAssetsMode() (bool, error) {
+	return s.GetMultiAssetsModeContext(context.Background())
+}
+
+// GetMultiAssetsModeContext reports whether multi-assets margin mode is enabled
+func (s *AccountService) GetMultiAssetsModeContext(ctx context.Context) (bool, error) {
+	req := request{
+		method:   http.MethodGet,
+		endpoint: endPointMultiAssets,
+		secType:  secTypeSigned,
+	}
+
+	data, err := s.c.callAPI(ctx, &req)
+	if err != nil {
+		return false, err
+	}
+
+	var res jsonMultiAssetsMode
 	err = json.Unmarshal(data, &res)
 	if err != nil {
-		log.Println("error in parsing margin type response json : ", err, string(data))
-		return false
+		s.c.logger.Error("error in parsing multi-assets mode json", "err", err, "data", string(data))
+		return false, err
+	}
+	return res.MultiAssetsMargin, nil
+}
+
+func (s *AccountService) SetMultiAssetsMode(enabled bool) error {
+	return s.SetMultiAssetsModeContext(context.Background(), enabled)
+}
+
+// SetMultiAssetsModeContext enables or disables multi-assets margin mode,
+// setting the current mode is not an error
+func (s *AccountService) SetMultiAssetsModeContext(ctx context.Context, enabled bool) error {
+	req := request{
+		method:   http.MethodPost,
+		endpoint: endPointMultiAssets,
+		secType:  secTypeSigned,
+		weight:   1,
+	}
+	req.setParam(key_MULTI_ASSETS_MARGIN, strconv.FormatBool(enabled))
+
+	_, err := s.c.callAPI(ctx, &req)
+	if err != nil && !errors.Is(err, ErrNoNeedToChangeMultiAssets) {
+		return err
+	}
+	return nil
+}
+
+func (s *AccountService) AddPositionMargin(symbol string, positionSide PositionSideType, amount Decimal) error {
+	return s.AddPositionMarginContext(context.Background(), symbol, positionSide, amount)
+}
+
+// AddPositionMarginContext moves amount from the wallet to the margin of an
+// isolated position, positionSide is BOTH (or empty) in one-way mode
+func (s *AccountService) AddPositionMarginContext(ctx context.Context, symbol string, positionSide PositionSideType, amount Decimal) error {
+	return s.updatePositionMargin(ctx, symbol, positionSide, amount, PositionMarginTypeAdd)
+}
+
+func (s *AccountService) ReducePositionMargin(symbol string, positionSide PositionSideType, amount Decimal) error {
+	return s.ReducePositionMarginContext(context.Background(), symbol, positionSide, amount)
+}
+
+// ReducePositionMarginContext moves amount from the margin of an isolated position back to the wallet
+func (s *AccountService) ReducePositionMarginContext(ctx context.Context, symbol string, positionSide PositionSideType, amount Decimal) error {
+	return s.updatePositionMargin(ctx, symbol, positionSide, amount, PositionMarginTypeReduce)
+}
+
+func (s *AccountService) updatePositionMargin(ctx context.Context, symbol string, positionSide PositionSideType, amount Decimal, marginType PositionMarginType) error {
+	if !amount.IsPositive() {
+		return &OrderValidationError{Symbol: symbol, Field: "amount", Reason: "amount must be positive"}
+	}
+
+	req := request{
+		method:   http.MethodPost,
+		endpoint: endPointPositionMargin,
+		secType:  secTypeSigned,
+	}
+	req.setParam(key_SYMBOL, symbol)
+	if positionSide != "" {
+		req.setParam(key_POSITION_SIDE, positionSide)
+	}
+	req.setParam(key_AMOUNT, amount.String())
+	req.setParam(key_POSITION_MARGIN_TYPE, int(marginType))
+
+	_, err := s.c.callAPI(ctx, &req)
+	if err != nil {
+		s.c.logger.Error("error in updating position margin", "err", err, "symbol", symbol, "positionSide", positionSide, "amount", amount)
+	}
+	return err
+}
+
+type jsonPositionMarginChange struct {
+	Symbol       string             `json:"symbol"`
+	PositionSide PositionSideType   `json:"positionSide"`
+	Type         PositionMarginType `json:"type"`
+	DeltaType    string             `json:"deltaType"`
+	Amount       string             `json:"amount"`
+	Asset        string             `json:"asset"`
+	Time         int64              `json:"time"`
+}
+
+// PositionMarginHistoryQuery selects the margin changes of a symbol, of
+// both types when Type is zero
+type PositionMarginHistoryQuery struct {
+	Symbol    string
+	Type      PositionMarginType
+	StartTime int64
+	EndTime   int64
+	Limit     int // default 500
+}
+
+func (s *AccountService) GetPositionMarginHistory(query *PositionMarginHistoryQuery) ([]*PositionMarginChange, error) {
+	return s.GetPositionMarginHistoryContext(context.Background(), query)
+}
+
+func (s *AccountService) GetPositionMarginHistoryContext(ctx context.Context, query *PositionMarginHistoryQuery) ([]*PositionMarginChange, error) {
+	req := request{
+		method:   http.MethodGet,
+		endpoint: endPointMarginHistory,
+		secType:  secTypeSigned,
+	}
+	req.setParam(key_SYMBOL, query.Symbol)
+	if query.Type != 0 {
+		req.setParam(key_POSITION_MARGIN_TYPE, int(query.Type))
+	}
+	if query.StartTime > 0 {
+		req.setParam(key_STARTTIME, query.StartTime)
+	}
+	if query.EndTime > 0 {
+		req.setParam(key_ENDTIME, query.EndTime)
+	}
+	if query.Limit > 0 {
+		req.setParam(key_LIMIT, query.Limit)
+	}
+
+	data, err := s.c.callAPI(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	var resList []jsonPositionMarginChange
+	err = json.Unmarshal(data, &resList)
+	if err != nil {
+		s.c.logger.Error("error in parsing position margin history json", "err", err, "data", string(data))
+		return nil, err
+	}
+	changes := make([]*PositionMarginChange, 0, len(resList))
+	for _, res := range resList {
+		changes = append(changes, &PositionMarginChange{
+			Symbol:       res.Symbol,
+			PositionSide: res.PositionSide,
+			Type:         res.Type,
+			DeltaType:    res.DeltaType,
+			Amount:       parseDecimal(res.Amount),
+			Asset:        res.Asset,
+			Time:         res.Time,
+		})
 	}
-	return res.Code == 200
+	return changes, nil
 }
@@ -2,8 +2,9 @@ package binance
 
 /* place and cancel orders */
 import (
+	"context"
 	"encoding/json"
-	"log"
+	"errors"
 	"net/http"
 	"strconv"
 )
@@ -28,6 +29,10 @@ type OrderService struct {
 	PriceProtect     bool
 	NewOrderRespType NewOrderRespType
 	RecvWindow       string
+
+	GoodTillDate            int64
+	SelfTradePreventionMode SelfTradePreventionMode
+	PriceMatch              PriceMatchType
 }
 
 /* places an order and returns response with order it
@@ -40,13 +45,38 @@ type OrderService struct {
  * *  STOP_MARKET/TAKE_PROFIT_MARKET	stopPrice
  * * TRAILING_STOP_MARKET				callbackRate
  **/
-func (order *OrderService) placeOrder() (*OrderResponse, error) {
+func (order *OrderService) placeOrder(ctx context.Context) (*OrderResponse, error) {
 	req := request{
 		method:   http.MethodPost,
 		endpoint: endPointOrder,
 		secType:  secTypeSigned,
+		orders:   1,
+	}
+
+	params := order.params()
+	if order.RecvWindow == "" {
+		order.RecvWindow = "2000" // 2 seconds by default
 	}
 
+	req.setParams(params)
+	recvWindow, _ := strconv.ParseInt(order.RecvWindow, 10, 64)
+	if recvWindow == 0 {
+		req.recvWindow = 2000
+	}
+	data, err := order.c.callAPI(ctx, &req)
+	if err != nil {
+		order.c.logger.Error("error in placing order", "err", err, "symbol", order.Symbol, "side", order.Side, "type", order.OrderType, "clientOrderId", order.NewClientOrderId)
+		if errors.Is(err, ErrPositionSideMismatch) {
+			// the mode was changed elsewhere, forget the cached one
+			order.c.positionMode.Store(PositionMode(""))
+		}
+		return nil, err
+	}
+	return order.parseOrderResponse(data)
+}
+
+// params returns the order parameters set on the service, the same for single and batch orders
+func (order *OrderService) params() map[string]interface{} {
 	params := make(map[string]interface{})
 	params["symbol"] = order.Symbol
 	params["side"] = order.Side
@@ -94,24 +124,31 @@ func (order *OrderService) placeOrder() (*OrderResponse, error) {
 	if order.ClosePosition {
 		params["closePosition"] = order.ClosePosition
 	}
-	if order.RecvWindow == "" {
-		order.RecvWindow = "2000" // 2 seconds by default
+	if order.GoodTillDate > 0 {
+		params["goodTillDate"] = order.GoodTillDate
 	}
-
-	req.setParams(params)
-	recvWindow, _ := strconv.ParseInt(order.RecvWindow, 10, 64)
-	if recvWindow == 0 {
-		req.recvWindow = 2000
+	if order.SelfTradePreventionMode != "" {
+		params["selfTradePreventionMode"] = order.SelfTradePreventionMode
 	}
-	data, err := order.c.callAPI(&req)
-	if err != nil {
-		log.Println("error in placing order : ", err, order, string(data))
-		return nil, err
+	if order.PriceMatch != "" {
+		params["priceMatch"] = order.PriceMatch
 	}
-	return order.parseOrderResponse(data), nil
+	return params
 }
 
-func (order *OrderService) cancelOrder(symbol string, orderId int64) (*OrderResponse, error) {
+// testOrder validates the order on binance without sending it to the matching engine
+func (order *OrderService) testOrder(ctx context.Context) error {
+	req := request{
+		method:   http.MethodPost,
+		endpoint: endPointOrderTest,
+		secType:  secTypeSigned,
+	}
+	req.setParams(order.params())
+	_, err := order.c.callAPI(ctx, &req)
+	return err
+}
+
+func (order *OrderService) cancelOrder(ctx context.Context, symbol string, orderId int64) (*OrderResponse, error) {
 	req := request{
 		method:   http.MethodDelete,
 		endpoint: endPointOrder,
@@ -123,70 +160,89 @@ func (order *OrderService) cancelOrder(symbol string, orderId int64) (*OrderResp
 	params["orderId"] = orderId
 	req.setParams(params)
 	req.recvWindow = 2000
-	data, err := order.c.callAPI(&req)
+	data, err := order.c.callAPI(ctx, &req)
 	if err != nil {
-		log.Println("error in cancelling order : ", err, order, string(data))
+		order.c.logger.Error("error in cancelling order", "err", err, "symbol", symbol, "orderId", orderId)
 		return nil, err
 	}
 
-	return order.parseOrderResponse(data), nil
+	return order.parseOrderResponse(data)
 }
 
 type jsonOrderResponse struct {
-	ClientOrderId    string `json:"clientOrderId"`
-	CumQuantity      string `json:"cumQty"`
-	CumQuote         string `json:"cumQuote"`
-	ExecutedQuantity string `json:"executedQty"`
-	OrderId          int    `json:"orderId"`
-	AveragePrice     string `json:"avgPrice"`
-	OriginalQuantity string `json:"origQty"`
-	Price            string `json:"price"`
-	ReduceOnly       bool   `json:"reduceOnly"`
-	Side             string `json:"side"`
-	PositionSide     string `json:"positionSide"`
-	Status           string `json:"status"`
-	StopPrice        string `json:"stopPrice"`
-	ClosePosition    bool   `json:"closePosition"`
-	Symbol           string `json:"symbol"`
-	TimeInForce      string `json:"timeInForce"`
-	Type             string `json:"type"`
-	OriginalType     string `json:"origType"`
-	ActivationPrice  string `json:"activatePrice"`
-	PriceRate        string `json:"priceRate"`
-	UpdateTime       int64  `json:"updateTime"`
-	WorkingType      string `json:"workingType"`
-	PriceProtect     bool   `json:"priceProtect"`
+	ClientOrderId    string           `json:"clientOrderId"`
+	CumQuantity      string           `json:"cumQty"`
+	CumQuote         string           `json:"cumQuote"`
+	ExecutedQuantity string           `json:"executedQty"`
+	OrderId          int64            `json:"orderId"`
+	AveragePrice     string           `json:"avgPrice"`
+	OriginalQuantity string           `json:"origQty"`
+	Price            string           `json:"price"`
+	ReduceOnly       bool             `json:"reduceOnly"`
+	Side             SideType         `json:"side"`
+	PositionSide     PositionSideType `json:"positionSide"`
+	Status           OrderStatusType  `json:"status"`
+	StopPrice        string           `json:"stopPrice"`
+	ClosePosition    bool             `json:"closePosition"`
+	Symbol           string           `json:"symbol"`
+	TimeInForce      TimeInForceType  `json:"timeInForce"`
+	Type             OrderType        `json:"type"`
+	OriginalType     OrderType        `json:"origType"`
+	ActivationPrice  string           `json:"activatePrice"`
+	PriceRate        string           `json:"priceRate"`
+	Time             int64            `json:"time"`
+	UpdateTime       int64            `json:"updateTime"`
+	WorkingType      WorkingType      `json:"workingType"`
+	PriceProtect     bool             `json:"priceProtect"`
 }
 
-func (order *OrderService) parseOrderResponse(data []byte) *OrderResponse {
+func (order *OrderService) parseOrderResponse(data []byte) (*OrderResponse, error) {
 	var res jsonOrderResponse
 	err := json.Unmarshal(data, &res)
 	if err != nil {
-		log.Println("error in parsing order response : ", err, string(data))
-		return nil
+		order.c.logger.Error("error in parsing order response", "err", err, "data", string(data))
+		return nil, err
 	}
+	return res.toOrderResponse(), nil
+}
+
+func (order *OrderService) parseOrderResponses(data []byte) ([]*OrderResponse, error) {
+	var resList []jsonOrderResponse
+	err := json.Unmarshal(data, &resList)
+	if err != nil {
+		order.c.logger.Error("error in parsing order list response", "err", err, "data", string(data))
+		return nil, err
+	}
+	orders := make([]*OrderResponse, 0, len(resList))
+	for _, res := range resList {
+		orders = append(orders, res.toOrderResponse())
+	}
+	return orders, nil
+}
 
+func (res *jsonOrderResponse) toOrderResponse() *OrderResponse {
 	return &OrderResponse{
 		ClientOrderId:    res.ClientOrderId,
-		CumQuantity:      parseFloat(res.CumQuantity),
-		CumQuote:         parseFloat(res.CumQuote),
-		ExecutedQuantity: parseFloat(res.ExecutedQuantity),
+		CumQuantity:      parseDecimal(res.CumQuantity),
+		CumQuote:         parseDecimal(res.CumQuote),
+		ExecutedQuantity: parseDecimal(res.ExecutedQuantity),
 		OrderId:          res.OrderId,
-		AveragePrice:     parseFloat(res.AveragePrice),
-		OriginalQuantity: parseFloat(res.OriginalQuantity),
-		Price:            parseFloat(res.Price),
+		AveragePrice:     parseDecimal(res.AveragePrice),
+		OriginalQuantity: parseDecimal(res.OriginalQuantity),
+		Price:            parseDecimal(res.Price),
 		ReduceOnly:       res.ReduceOnly,
 		Side:             res.Side,
 		PositionSide:     res.PositionSide,
 		Status:           res.Status,
-		StopPrice:        parseFloat(res.StopPrice),
+		StopPrice:        parseDecimal(res.StopPrice),
 		ClosePosition:    res.ClosePosition,
 		Symbol:           res.Symbol,
 		TimeInForce:      res.TimeInForce,
 		Type:             res.Type,
 		OriginalType:     res.OriginalType,
-		ActivationPrice:  parseFloat(res.ActivationPrice),
-		PriceRate:        parseFloat(res.PriceRate),
+		ActivationPrice:  parseDecimal(res.ActivationPrice),
+		PriceRate:        parseDecimal(res.PriceRate),
+		Time:             res.Time,
 		UpdateTime:       res.UpdateTime,
 		WorkingType:      res.WorkingType,
 		PriceProtect:     res.PriceProtect,
@@ -198,7 +254,7 @@ type jsonCancelAllOrders struct {
 	Message string `json:"msg"`
 }
 
-func (order *OrderService) cancelAllOpenOrders(symbol string) bool {
+func (order *OrderService) cancelAllOpenOrders(ctx context.Context, symbol string) (bool, error) {
 	req := request{
 		method:   http.MethodDelete,
 		endpoint: endPointAllOpenOrders,
@@ -206,16 +262,16 @@ func (order *OrderService) cancelAllOpenOrders(symbol string) bool {
 	}
 	req.setParam("symbol", symbol)
 	req.recvWindow = 2000
-	data, err := order.c.callAPI(&req)
+	data, err := order.c.callAPI(ctx, &req)
 	if err != nil {
-		return false
+		return false, err
 	}
 
 	var res jsonCancelAllOrders
 	err = json.Unmarshal(data, &res)
 	if err != nil {
-		log.Println("error in parsing cancelAll response : ", err, string(data))
-		return false
+		order.c.logger.Error("error in parsing cancelAll response", "err", err, "data", string(data))
+		return false, err
 	}
-	return res.Code == 200
+	return res.Code == 200, nil
 }
@@ -0,0 +1,223 @@
+package binance
+
+/* chainable order builder */
+import (
+	"context"
+	"time"
+)
+
+// OrderBuilder builds an order step by step and places it, e.g.
+//
+//	accountService.NewOrder("BTCUSDT").Buy().Limit(price).Qty(q).GTX().ReduceOnly().ClientID(id).Place()
+//
+// prices and quantity are formatted against the symbol filters, and the
+// mandatory parameters of the order type are checked before sending. an
+// OrderBuilder is an Order, so it can be placed in a batch as well
+type OrderBuilder struct {
+	s      *AccountService
+	info   *InfoSymbol
+	symbol string
+	params OrderParams
+	opts   orderOptions
+}
+
+// NewOrder starts an order for symbol, LIMIT orders are GTC unless another
+// time in force is set
+func (s *AccountService) NewOrder(symbol string) *OrderBuilder {
+	return &OrderBuilder{s: s, symbol: symbol}
+}
+
+// Info sets the symbol info used to format the order instead of the symbol registry
+func (b *OrderBuilder) Info(info *InfoSymbol) *OrderBuilder {
+	b.info = info
+	return b
+}
+
+func (b *OrderBuilder) Buy() *OrderBuilder {
+	b.params.Side = SideTypeBuy
+	return b
+}
+
+func (b *OrderBuilder) Sell() *OrderBuilder {
+	b.params.Side = SideTypeSell
+	return b
+}
+
+func (b *OrderBuilder) Side(side SideType) *OrderBuilder {
+	b.params.Side = side
+	return b
+}
+
+func (b *OrderBuilder) Limit(price Decimal) *OrderBuilder {
+	b.params.Type = OrderTypeLimit
+	b.params.Price = price
+	return b
+}
+
+func (b *OrderBuilder) Market() *OrderBuilder {
+	b.params.Type = OrderTypeMarket
+	return b
+}
+
+// Stop is a stop limit order, placed at price once stopPrice is reached
+func (b *OrderBuilder) Stop(price, stopPrice Decimal) *OrderBuilder {
+	b.params.Type = OrderTypeStop
+	b.params.Price = price
+	b.params.StopPrice = stopPrice
+	return b
+}
+
+// TakeProfit is a take profit limit order, placed at price once stopPrice is reached
+func (b *OrderBuilder) TakeProfit(price, stopPrice Decimal) *OrderBuilder {
+	b.params.Type = OrderTypeTakeProfit
+	b.params.Price = price
+	b.params.StopPrice = stopPrice
+	return b
+}
+
+func (b *OrderBuilder) StopMarket(stopPrice Decimal) *OrderBuilder {
+	b.params.Type = OrderTypeStopMarket
+	b.params.StopPrice = stopPrice
+	return b
+}
+
+func (b *OrderBuilder) TakeProfitMarket(stopPrice Decimal) *OrderBuilder {
+	b.params.Type = OrderTypeTakeProfitMarket
+	b.params.StopPrice = stopPrice
+	return b
+}
+
+// TrailingStopMarket follows the price at callbackRate percent (0.1 to 10)
+func (b *OrderBuilder) TrailingStopMarket(callbackRate Decimal) *OrderBuilder {
+	b.params.Type = OrderTypeTrailingStopMarket
+	b.opts.CallbackRate = callbackRate
+	return b
+}
+
+// ActivationPrice sets the price that activates a trailing stop
+func (b *OrderBuilder) ActivationPrice(price Decimal) *OrderBuilder {
+	b.opts.ActivationPrice = price
+	return b
+}
+
+func (b *OrderBuilder) Qty(quantity Decimal) *OrderBuilder {
+	b.params.Quantity = quantity
+	return b
+}
+
+// PriceMatch prices the order from the order book instead of a fixed price
+func (b *OrderBuilder) PriceMatch(priceMatch PriceMatchType) *OrderBuilder {
+	b.params.Price = Decimal{}
+	b.opts.PriceMatch = priceMatch
+	return b
+}
+
+// MarkPrice sets the mark price used for the PERCENT_PRICE and MIN_NOTIONAL checks
+func (b *OrderBuilder) MarkPrice(price Decimal) *OrderBuilder {
+	b.params.MarkPrice = price
+	return b
+}
+
+func (b *OrderBuilder) TimeInForce(timeInForce TimeInForceType) *OrderBuilder {
+	b.opts.TimeInForce = timeInForce
+	return b
+}
+
+func (b *OrderBuilder) GTC() *OrderBuilder {
+	return b.TimeInForce(TimeInForceTypeGTC)
+}
+
+func (b *OrderBuilder) IOC() *OrderBuilder {
+	return b.TimeInForce(TimeInForceTypeIOC)
+}
+
+func (b *OrderBuilder) FOK() *OrderBuilder {
+	return b.TimeInForce(TimeInForceTypeFOK)
+}
+
+// GTX is post only, the order is expired instead of taking liquidity
+func (b *OrderBuilder) GTX() *OrderBuilder {
+	return b.TimeInForce(TimeInForceTypeGTX)
+}
+
+// GTD keeps the order open until t, at least 10 minutes from now
+func (b *OrderBuilder) GTD(t time.Time) *OrderBuilder {
+	b.opts.GoodTillDate = t.UnixMilli()
+	return b.TimeInForce(TimeInForceTypeGTD)
+}
+
+func (b *OrderBuilder) ReduceOnly() *OrderBuilder {
+	b.params.ReduceOnly = true
+	return b
+}
+
+// ClosePosition closes the whole position, for STOP_MARKET and TAKE_PROFIT_MARKET orders without quantity
+func (b *OrderBuilder) ClosePosition() *OrderBuilder {
+	b.opts.ClosePosition = true
+	return b
+}
+
+func (b *OrderBuilder) PositionSide(positionSide PositionSideType) *OrderBuilder {
+	b.opts.PositionSide = positionSide
+	return b
+}
+
+func (b *OrderBuilder) WorkingType(workingType WorkingType) *OrderBuilder {
+	b.opts.WorkingType = workingType
+	return b
+}
+
+func (b *OrderBuilder) PriceProtect() *OrderBuilder {
+	b.opts.PriceProtect = true
+	return b
+}
+
+func (b *OrderBuilder) SelfTradePrevention(mode SelfTradePreventionMode) *OrderBuilder {
+	b.opts.SelfTradePreventionMode = mode
+	return b
+}
+
+// ClientID sets the client order id, orders with a client order id are
+// retried on transient failures
+func (b *OrderBuilder) ClientID(clientOrderId string) *OrderBuilder {
+	b.opts.NewClientOrderId = clientOrderId
+	return b
+}
+
+func (b *OrderBuilder) RespType(respType NewOrderRespType) *OrderBuilder {
+	b.opts.NewOrderRespType = respType
+	return b
+}
+
+func (b *OrderBuilder) orderSymbol() string { return b.symbol }
+func (b *OrderBuilder) orderParams() OrderParams {
+	return b.params
+}
+func (b *OrderBuilder) orderOptions() orderOptions {
+	opts := b.opts
+	if b.params.Type == OrderTypeLimit && opts.TimeInForce == "" {
+		opts.TimeInForce = TimeInForceTypeGTC
+	}
+	return opts
+}
+
+func (b *OrderBuilder) Place() (*OrderResponse, error) {
+	return b.PlaceContext(context.Background())
+}
+
+func (b *OrderBuilder) PlaceContext(ctx context.Context) (*OrderResponse, error) {
+	return b.s.placeOrder(ctx, b.info, b)
+}
+
+// Test sends the order to /fapi/v1/order/test, binance validates it without placing it
+func (b *OrderBuilder) Test() error {
+	return b.TestContext(context.Background())
+}
+
+func (b *OrderBuilder) TestContext(ctx context.Context) error {
+	orderService, err := b.s.newOrderService(ctx, b.info, b)
+	if err != nil {
+		return err
+	}
+	return orderService.testOrder(ctx)
+}
@@ -0,0 +1,193 @@
+package binance
+
+/* modify open limit orders in place and list their amendments */
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+func (s *AccountService) ModifyOrder(order *ModifyOrderRequest) (*OrderResponse, error) {
+	return s.ModifyOrderContext(context.Background(), order)
+}
+
+// ModifyOrderContext changes the price and quantity of an open limit order
+// without cancelling it, the new price and quantity are rounded to the
+// symbol filters like new orders
+func (s *AccountService) ModifyOrderContext(ctx context.Context, order *ModifyOrderRequest) (*OrderResponse, error) {
+	params, err := s.modifyParams(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+
+	req := request{
+		method:   http.MethodPut,
+		endpoint: endPointOrder,
+		secType:  secTypeSigned,
+		orders:   1,
+	}
+	req.setParams(params)
+
+	orderService := OrderService{c: s.c}
+	data, err := s.c.callAPI(ctx, &req)
+	if err != nil {
+		s.c.logger.Error("error in modifying order", "err", err, "symbol", order.Symbol, "orderId", order.OrderId, "clientOrderId", order.OrigClientOrderId)
+		return nil, err
+	}
+	return orderService.parseOrderResponse(data)
+}
+
+// modifyParams rounds the new price and quantity to the symbol filters and
+// returns the parameters of the modification
+func (s *AccountService) modifyParams(ctx context.Context, order *ModifyOrderRequest) (map[string]interface{}, error) {
+	priceMatch := order.PriceMatch != "" && order.PriceMatch != PriceMatchTypeNone
+	if err := validateModifyOrder(order, priceMatch); err != nil {
+		s.c.logger.Warn("error in validating order modification", "err", err, "symbol", order.Symbol)
+		return nil, err
+	}
+
+	info, err := s.c.registry.SymbolContext(ctx, order.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	price := order.Price
+	if priceMatch {
+		price = Decimal{}
+	}
+	p, err := info.NormalizeOrder(OrderParams{
+		Type:     OrderTypeLimit,
+		Side:     order.Side,
+		Price:    price,
+		Quantity: order.Quantity,
+	})
+	if err != nil {
+		s.c.logger.Warn("error in validating order modification", "err", err, "symbol", order.Symbol)
+		return nil, err
+	}
+
+	params := make(map[string]interface{})
+	params["symbol"] = order.Symbol
+	params["side"] = order.Side
+	params["quantity"] = formatDecimal(p.Quantity)
+	if priceMatch {
+		params["priceMatch"] = order.PriceMatch
+	} else {
+		params["price"] = formatDecimal(p.Price)
+	}
+	if order.OrderId > 0 {
+		params["orderId"] = order.OrderId
+	}
+	if order.OrigClientOrderId != "" {
+		params["origClientOrderId"] = order.OrigClientOrderId
+	}
+	return params, nil
+}
+
+// validateModifyOrder checks the mandatory fields of a modification, binance
+// requires the order id or client order id, side and quantity, and price
+// unless priceMatch is set
+func validateModifyOrder(order *ModifyOrderRequest, priceMatch bool) error {
+	invalid := func(field, format string, args ...interface{}) error {
+		return &OrderValidationError{Symbol: order.Symbol, Field: field, Reason: fmt.Sprintf(format, args...)}
+	}
+	if order.OrderId <= 0 && order.OrigClientOrderId == "" {
+		return invalid("orderId", "orderId or origClientOrderId is required to modify an order")
+	}
+	if order.Side != SideTypeBuy && order.Side != SideTypeSell {
+		return invalid("side", "side must be BUY or SELL")
+	}
+	if !order.Quantity.IsPositive() {
+		return invalid("quantity", "quantity is required to modify an order")
+	}
+	if order.Price.IsZero() && !priceMatch {
+		return invalid("price", "price or priceMatch is required to modify an order")
+	}
+	return nil
+}
+
+type jsonAmendmentValue struct {
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+type jsonOrderAmendment struct {
+	AmendmentId   int64  `json:"amendmentId"`
+	Symbol        string `json:"symbol"`
+	Pair          string `json:"pair"`
+	OrderId       int64  `json:"orderId"`
+	ClientOrderId string `json:"clientOrderId"`
+	Time          int64  `json:"time"`
+	Amendment     struct {
+		Price    jsonAmendmentValue `json:"price"`
+		Quantity jsonAmendmentValue `json:"origQty"`
+		Count    int64              `json:"count"`
+	} `json:"amendment"`
+}
+
+// OrderAmendmentsQuery selects the amendments of an order, by OrderId or OrigClientOrderId
+type OrderAmendmentsQuery struct {
+	Symbol            string
+	OrderId           int64
+	OrigClientOrderId string
+	StartTime         int64
+	EndTime           int64
+	Limit             int // default 50, max 100
+}
+
+func (s *AccountService) GetOrderAmendments(query *OrderAmendmentsQuery) ([]*OrderAmendment, error) {
+	return s.GetOrderAmendmentsContext(context.Background(), query)
+}
+
+func (s *AccountService) GetOrderAmendmentsContext(ctx context.Context, query *OrderAmendmentsQuery) ([]*OrderAmendment, error) {
+	req := request{
+		method:   http.MethodGet,
+		endpoint: endPointOrderAmendment,
+		secType:  secTypeSigned,
+	}
+	req.setParam(key_SYMBOL, query.Symbol)
+	if query.OrderId > 0 {
+		req.setParam(key_ORDER_ID, query.OrderId)
+	}
+	if query.OrigClientOrderId != "" {
+		req.setParam(key_ORIG_CLIENT_ORDER_ID, query.OrigClientOrderId)
+	}
+	if query.StartTime > 0 {
+		req.setParam(key_STARTTIME, query.StartTime)
+	}
+	if query.EndTime > 0 {
+		req.setParam(key_ENDTIME, query.EndTime)
+	}
+	if query.Limit > 0 {
+		req.setParam(key_LIMIT, query.Limit)
+	}
+
+	data, err := s.c.callAPI(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	var resList []jsonOrderAmendment
+	err = json.Unmarshal(data, &resList)
+	if err != nil {
+		s.c.logger.Error("error in parsing order amendment json", "err", err, "data", string(data))
+		return nil, err
+	}
+	amendments := make([]*OrderAmendment, 0, len(resList))
+	for _, res := range resList {
+		amendments = append(amendments, &OrderAmendment{
+			AmendmentId:    res.AmendmentId,
+			Symbol:         res.Symbol,
+			Pair:           res.Pair,
+			OrderId:        res.OrderId,
+			ClientOrderId:  res.ClientOrderId,
+			PriceBefore:    parseDecimal(res.Amendment.Price.Before),
+			PriceAfter:     parseDecimal(res.Amendment.Price.After),
+			QuantityBefore: parseDecimal(res.Amendment.Quantity.Before),
+			QuantityAfter:  parseDecimal(res.Amendment.Quantity.After),
+			Count:          res.Amendment.Count,
+			Time:           res.Time,
+		})
+	}
+	return amendments, nil
+}
@@ -0,0 +1,78 @@
+package binance
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestValidateModifyOrder(t *testing.T) {
+	valid := func() ModifyOrderRequest {
+		return ModifyOrderRequest{
+			Symbol:   "BTCUSDT",
+			OrderId:  42,
+			Side:     SideTypeBuy,
+			Quantity: MustParseDecimal("0.002"),
+			Price:    MustParseDecimal("27150.1"),
+		}
+	}
+	tests := []struct {
+		name      string
+		edit      func(o *ModifyOrderRequest)
+		wantField string
+	}{
+		{"valid", func(o *ModifyOrderRequest) {}, ""},
+		{"client order id only", func(o *ModifyOrderRequest) { o.OrderId, o.OrigClientOrderId = 0, "abc" }, ""},
+		{"price match", func(o *ModifyOrderRequest) { o.Price, o.PriceMatch = Decimal{}, PriceMatchTypeQueue }, ""},
+		{"no order id", func(o *ModifyOrderRequest) { o.OrderId = 0 }, "orderId"},
+		{"no side", func(o *ModifyOrderRequest) { o.Side = "" }, "side"},
+		{"no quantity", func(o *ModifyOrderRequest) { o.Quantity = Decimal{} }, "quantity"},
+		{"negative quantity", func(o *ModifyOrderRequest) { o.Quantity = MustParseDecimal("-1") }, "quantity"},
+		{"no price", func(o *ModifyOrderRequest) { o.Price = Decimal{} }, "price"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := valid()
+			tt.edit(&order)
+			priceMatch := order.PriceMatch != "" && order.PriceMatch != PriceMatchTypeNone
+			err := validateModifyOrder(&order, priceMatch)
+			if tt.wantField == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			var verr *OrderValidationError
+			if !errors.Is(err, ErrInvalidOrder) || !errors.As(err, &verr) || verr.Field != tt.wantField {
+				t.Fatalf("err = %v, want a %s validation error", err, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestModifyBatchOrdersWithoutId(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer srv.Close()
+	s := NewClient("k", "s", WithBaseURL(srv.URL)).NewAccountService()
+
+	results, err := s.ModifyBatchOrders(&ModifyOrderRequest{
+		Symbol:   "BTCUSDT",
+		Side:     SideTypeSell,
+		Quantity: MustParseDecimal("0.002"),
+		Price:    MustParseDecimal("27150.1"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || !errors.Is(results[0].Err, ErrInvalidOrder) {
+		t.Fatalf("results = %+v, want an invalid order", results)
+	}
+	if n := atomic.LoadInt32(&calls); n != 0 {
+		t.Errorf("server called %d times", n)
+	}
+}
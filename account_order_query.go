@@ -0,0 +1,196 @@
+package binance
+
+/* look up orders by id, open orders and order history */
+import (
+	"context"
+	"net/http"
+)
+
+func (s *AccountService) GetOrder(symbol string, orderId int64) (*OrderResponse, error) {
+	return s.GetOrderContext(context.Background(), symbol, orderId)
+}
+
+// GetOrderContext returns an order by id, open or not
+func (s *AccountService) GetOrderContext(ctx context.Context, symbol string, orderId int64) (*OrderResponse, error) {
+	return s.queryOrder(ctx, endPointOrder, symbol, orderId, "")
+}
+
+func (s *AccountService) GetOrderByClientOrderId(symbol, clientOrderId string) (*OrderResponse, error) {
+	return s.GetOrderByClientOrderIdContext(context.Background(), symbol, clientOrderId)
+}
+
+// GetOrderByClientOrderIdContext returns an order by its client order id, open or not
+func (s *AccountService) GetOrderByClientOrderIdContext(ctx context.Context, symbol, clientOrderId string) (*OrderResponse, error) {
+	return s.queryOrder(ctx, endPointOrder, symbol, 0, clientOrderId)
+}
+
+func (s *AccountService) GetOpenOrder(symbol string, orderId int64) (*OrderResponse, error) {
+	return s.GetOpenOrderContext(context.Background(), symbol, orderId)
+}
+
+// GetOpenOrderContext returns an open order by id, binance returns -2013 when it is not open
+func (s *AccountService) GetOpenOrderContext(ctx context.Context, symbol string, orderId int64) (*OrderResponse, error) {
+	return s.queryOrder(ctx, endPointOpenOrder, symbol, orderId, "")
+}
+
+func (s *AccountService) GetOpenOrderByClientOrderId(symbol, clientOrderId string) (*OrderResponse, error) {
+	return s.GetOpenOrderByClientOrderIdContext(context.Background(), symbol, clientOrderId)
+}
+
+func (s *AccountService) GetOpenOrderByClientOrderIdContext(ctx context.Context, symbol, clientOrderId string) (*OrderResponse, error) {
+	return s.queryOrder(ctx, endPointOpenOrder, symbol, 0, clientOrderId)
+}
+
+func (s *AccountService) queryOrder(ctx context.Context, endpoint, symbol string, orderId int64, clientOrderId string) (*OrderResponse, error) {
+	req := request{
+		method:   http.MethodGet,
+		endpoint: endpoint,
+		secType:  secTypeSigned,
+	}
+	req.setParam(key_SYMBOL, symbol)
+	if orderId > 0 {
+		req.setParam(key_ORDER_ID, orderId)
+	}
+	if clientOrderId != "" {
+		req.setParam(key_ORIG_CLIENT_ORDER_ID, clientOrderId)
+	}
+
+	orderService := OrderService{c: s.c}
+	data, err := s.c.callAPI(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+	return orderService.parseOrderResponse(data)
+}
+
+func (s *AccountService) GetOpenOrders(symbol string) ([]*OrderResponse, error) {
+	return s.GetOpenOrdersContext(context.Background(), symbol)
+}
+
+// GetOpenOrdersContext returns the open orders of a symbol, or of every symbol when symbol is empty
+func (s *AccountService) GetOpenOrdersContext(ctx context.Context, symbol string) ([]*OrderResponse, error) {
+	req := request{
+		method:   http.MethodGet,
+		endpoint: endPointOpenOrders,
+		secType:  secTypeSigned,
+	}
+	if symbol != "" {
+		req.setParam(key_SYMBOL, symbol)
+		req.weight = 1
+	}
+
+	orderService := OrderService{c: s.c}
+	data, err := s.c.callAPI(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+	return orderService.parseOrderResponses(data)
+}
+
+// AllOrdersQuery selects a single page of order history, binance returns
+// orders with orderId >= OrderId, or within StartTime and EndTime (at most
+// 7 days apart), or the most recent ones
+type AllOrdersQuery struct {
+	Symbol    string
+	OrderId   int64
+	StartTime int64
+	EndTime   int64
+	Limit     int // default 500, max 1000
+}
+
+func (s *AccountService) GetAllOrders(query *AllOrdersQuery) ([]*OrderResponse, error) {
+	return s.GetAllOrdersContext(context.Background(), query)
+}
+
+func (s *AccountService) GetAllOrdersContext(ctx context.Context, query *AllOrdersQuery) ([]*OrderResponse, error) {
+	req := request{
+		method:   http.MethodGet,
+		endpoint: endPointAllOrders,
+		secType:  secTypeSigned,
+	}
+	req.setParam(key_SYMBOL, query.Symbol)
+	if query.OrderId > 0 {
+		req.setParam(key_ORDER_ID, query.OrderId)
+	}
+	if query.StartTime > 0 {
+		req.setParam(key_STARTTIME, query.StartTime)
+	}
+	if query.EndTime > 0 {
+		req.setParam(key_ENDTIME, query.EndTime)
+	}
+	if query.Limit > 0 {
+		req.setParam(key_LIMIT, query.Limit)
+	}
+
+	orderService := OrderService{c: s.c}
+	data, err := s.c.callAPI(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+	return orderService.parseOrderResponses(data)
+}
+
+// AllOrdersIterator pages through the order history of a symbol between two
+// times. it walks the range in 7 day windows until it finds orders, then
+// follows the order ids so pages of 1000 orders are never cut short.
+//
+//	it := accountService.NewAllOrdersIterator("BTCUSDT", startTime, endTime)
+//	for it.Next(ctx) {
+//		orders := it.Page()
+//	}
+//	if err := it.Err(); err != nil { ... }
+type AllOrdersIterator struct {
+	s      *AccountService
+	symbol string
+	pager  historyPager
+	page   []*OrderResponse
+}
+
+// NewAllOrdersIterator iterates orders created between startTime and endTime
+// (milliseconds), endTime zero means now. a zero startTime or an endTime
+// before it fails with ErrInvalidTimeRange
+func (s *AccountService) NewAllOrdersIterator(symbol string, startTime, endTime int64) *AllOrdersIterator {
+	return &AllOrdersIterator{
+		s:      s,
+		symbol: symbol,
+		pager:  newHistoryPager(startTime, endTime),
+	}
+}
+
+// Next fetches the next non empty page, it returns false when done or on error
+func (it *AllOrdersIterator) Next(ctx context.Context) bool {
+	var orders []*OrderResponse
+	n, ok := it.pager.next(func(startTime, endTime, fromId int64) ([]historyKey, error) {
+		var err error
+		orders, err = it.s.GetAllOrdersContext(ctx, &AllOrdersQuery{
+			Symbol:    it.symbol,
+			OrderId:   fromId,
+			StartTime: startTime,
+			EndTime:   endTime,
+			Limit:     maxHistoryLimit,
+		})
+		if err != nil {
+			return nil, err
+		}
+		keys := make([]historyKey, 0, len(orders))
+		for _, order := range orders {
+			keys = append(keys, historyKey{Id: order.OrderId, Time: order.Time})
+		}
+		return keys, nil
+	})
+	if !ok {
+		it.page = nil
+		return false
+	}
+	it.page = orders[:n]
+	return true
+}
+
+// Page returns the orders fetched by the last call to Next
+func (it *AllOrdersIterator) Page() []*OrderResponse {
+	return it.page
+}
+
+func (it *AllOrdersIterator) Err() error {
+	return it.pager.err
+}
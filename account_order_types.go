@@ -0,0 +1,324 @@
+package binance
+
+/* typed orders and the order options binance accepts for each type */
+import (
+	"fmt"
+	"time"
+)
+
+// Order is one of the typed orders (LimitOrder, MarketOrder, StopOrder,
+// TakeProfitOrder, StopMarketOrder, TakeProfitMarketOrder,
+// TrailingStopMarketOrder), accepted by single and batch order placement
+type Order interface {
+	orderSymbol() string
+	// orderParams returns the prices and quantity checked against the symbol filters
+	orderParams() OrderParams
+	// orderOptions returns the parameters that are not checked against the filters
+	orderOptions() orderOptions
+}
+
+type orderOptions struct {
+	PositionSide            PositionSideType
+	TimeInForce             TimeInForceType
+	GoodTillDate            int64
+	SelfTradePreventionMode SelfTradePreventionMode
+	PriceMatch              PriceMatchType
+	ClosePosition           bool
+	WorkingType             WorkingType
+	PriceProtect            bool
+	ActivationPrice         Decimal
+	CallbackRate            Decimal
+	NewClientOrderId        string
+	NewOrderRespType        NewOrderRespType
+}
+
+func (o *LimitOrder) orderSymbol() string { return o.Symbol }
+func (o *LimitOrder) orderParams() OrderParams {
+	return OrderParams{
+		Type:       OrderTypeLimit,
+		Side:       o.Side,
+		Price:      o.Price,
+		Quantity:   o.Quantity,
+		ReduceOnly: o.ReduceOnly,
+		MarkPrice:  o.MarkPrice,
+	}
+}
+func (o *LimitOrder) orderOptions() orderOptions {
+	return orderOptions{
+		PositionSide:            o.PositionSide,
+		TimeInForce:             o.TimeInForce,
+		GoodTillDate:            o.GoodTillDate,
+		SelfTradePreventionMode: o.SelfTradePreventionMode,
+		PriceMatch:              o.PriceMatch,
+		ClosePosition:           o.ClosePosition,
+		WorkingType:             o.WorkingType,
+		PriceProtect:            o.PriceProtect,
+	}
+}
+
+func (o *MarketOrder) orderSymbol() string { return o.Symbol }
+func (o *MarketOrder) orderParams() OrderParams {
+	return OrderParams{
+		Type:       OrderTypeMarket,
+		Side:       o.Side,
+		Quantity:   o.Quantity,
+		ReduceOnly: o.ReduceOnly,
+		MarkPrice:  o.MarkPrice,
+	}
+}
+func (o *MarketOrder) orderOptions() orderOptions {
+	return orderOptions{
+		PositionSide:            o.PositionSide,
+		TimeInForce:             o.TimeInForce,
+		GoodTillDate:            o.GoodTillDate,
+		SelfTradePreventionMode: o.SelfTradePreventionMode,
+		ClosePosition:           o.ClosePosition,
+		WorkingType:             o.WorkingType,
+		PriceProtect:            o.PriceProtect,
+	}
+}
+
+func (o *StopOrder) orderSymbol() string { return o.Symbol }
+func (o *StopOrder) orderParams() OrderParams {
+	return OrderParams{
+		Type:       OrderTypeStop,
+		Side:       o.Side,
+		Price:      o.Price,
+		StopPrice:  o.StopPrice,
+		Quantity:   o.Quantity,
+		ReduceOnly: o.ReduceOnly,
+		MarkPrice:  o.MarkPrice,
+	}
+}
+func (o *StopOrder) orderOptions() orderOptions {
+	return orderOptions{
+		PositionSide:            o.PositionSide,
+		TimeInForce:             o.TimeInForce,
+		GoodTillDate:            o.GoodTillDate,
+		SelfTradePreventionMode: o.SelfTradePreventionMode,
+		PriceMatch:              o.PriceMatch,
+		ClosePosition:           o.ClosePosition,
+		WorkingType:             o.WorkingType,
+		PriceProtect:            o.PriceProtect,
+	}
+}
+
+func (o *TakeProfitOrder) orderSymbol() string { return o.Symbol }
+func (o *TakeProfitOrder) orderParams() OrderParams {
+	return OrderParams{
+		Type:       OrderTypeTakeProfit,
+		Side:       o.Side,
+		Price:      o.Price,
+		StopPrice:  o.StopPrice,
+		Quantity:   o.Quantity,
+		ReduceOnly: o.ReduceOnly,
+		MarkPrice:  o.MarkPrice,
+	}
+}
+func (o *TakeProfitOrder) orderOptions() orderOptions {
+	return orderOptions{
+		PositionSide:            o.PositionSide,
+		TimeInForce:             o.TimeInForce,
+		GoodTillDate:            o.GoodTillDate,
+		SelfTradePreventionMode: o.SelfTradePreventionMode,
+		PriceMatch:              o.PriceMatch,
+		ClosePosition:           o.ClosePosition,
+		WorkingType:             o.WorkingType,
+		PriceProtect:            o.PriceProtect,
+	}
+}
+
+func (o *StopMarketOrder) orderSymbol() string { return o.Symbol }
+func (o *StopMarketOrder) orderParams() OrderParams {
+	return OrderParams{
+		Type:       OrderTypeStopMarket,
+		Side:       o.Side,
+		StopPrice:  o.StopPrice,
+		Quantity:   o.Quantity,
+		ReduceOnly: o.ReduceOnly,
+		MarkPrice:  o.MarkPrice,
+	}
+}
+func (o *StopMarketOrder) orderOptions() orderOptions {
+	return orderOptions{
+		PositionSide:            o.PositionSide,
+		TimeInForce:             o.TimeInForce,
+		GoodTillDate:            o.GoodTillDate,
+		SelfTradePreventionMode: o.SelfTradePreventionMode,
+		ClosePosition:           o.ClosePosition,
+		WorkingType:             o.WorkingType,
+		PriceProtect:            o.PriceProtect,
+	}
+}
+
+func (o *TakeProfitMarketOrder) orderSymbol() string { return o.Symbol }
+func (o *TakeProfitMarketOrder) orderParams() OrderParams {
+	return OrderParams{
+		Type:       OrderTypeTakeProfitMarket,
+		Side:       o.Side,
+		StopPrice:  o.StopPrice,
+		Quantity:   o.Quantity,
+		ReduceOnly: o.ReduceOnly,
+		MarkPrice:  o.MarkPrice,
+	}
+}
+func (o *TakeProfitMarketOrder) orderOptions() orderOptions {
+	return orderOptions{
+		PositionSide:            o.PositionSide,
+		TimeInForce:             o.TimeInForce,
+		GoodTillDate:            o.GoodTillDate,
+		SelfTradePreventionMode: o.SelfTradePreventionMode,
+		ClosePosition:           o.ClosePosition,
+		WorkingType:             o.WorkingType,
+		PriceProtect:            o.PriceProtect,
+	}
+}
+
+func (o *TrailingStopMarketOrder) orderSymbol() string { return o.Symbol }
+func (o *TrailingStopMarketOrder) orderParams() OrderParams {
+	return OrderParams{
+		Type:       OrderTypeTrailingStopMarket,
+		Side:       o.Side,
+		Quantity:   o.Quantity,
+		ReduceOnly: o.ReduceOnly,
+		MarkPrice:  o.MarkPrice,
+	}
+}
+func (o *TrailingStopMarketOrder) orderOptions() orderOptions {
+	return orderOptions{
+		PositionSide:            o.PositionSide,
+		TimeInForce:             o.TimeInForce,
+		GoodTillDate:            o.GoodTillDate,
+		SelfTradePreventionMode: o.SelfTradePreventionMode,
+		ClosePosition:           o.ClosePosition,
+		WorkingType:             o.WorkingType,
+		PriceProtect:            o.PriceProtect,
+		ActivationPrice:         o.ActivationPrice,
+		CallbackRate:            o.CallbackRate,
+	}
+}
+
+var (
+	minCallbackRate = NewDecimal(1, 1) // 0.1%
+	maxCallbackRate = NewDecimal(10, 0)
+	callbackStep    = NewDecimal(1, 1)
+
+	// goodTillDate must be at least 10 minutes ahead and before year 10000
+	minGoodTillDateDelay = 600 * time.Second
+	maxGoodTillDate      = int64(253402300799000)
+)
+
+func isConditionalType(orderType OrderType) bool {
+	switch orderType {
+	case OrderTypeStop, OrderTypeTakeProfit, OrderTypeStopMarket, OrderTypeTakeProfitMarket, OrderTypeTrailingStopMarket:
+		return true
+	}
+	return false
+}
+
+// validateOrder checks the mandatory parameters of the order type and the
+// combinations of options binance accepts, now is the server time in milliseconds
+//
+//	Type                            Mandatory Parameters
+//	LIMIT                           timeInForce, quantity, price or priceMatch
+//	MARKET                          quantity
+//	STOP/TAKE_PROFIT                quantity, price or priceMatch, stopPrice
+//	STOP_MARKET/TAKE_PROFIT_MARKET  stopPrice, quantity or closePosition
+//	TRAILING_STOP_MARKET            quantity, callbackRate
+func validateOrder(symbol string, p OrderParams, opts orderOptions, now int64) error {
+	invalid := func(field, format string, args ...interface{}) error {
+		return &OrderValidationError{Symbol: symbol, Field: field, Reason: fmt.Sprintf(format, args...)}
+	}
+
+	priceMatch := opts.PriceMatch != "" && opts.PriceMatch != PriceMatchTypeNone
+	switch p.Type {
+	case OrderTypeLimit, OrderTypeMarket, OrderTypeStop, OrderTypeTakeProfit,
+		OrderTypeStopMarket, OrderTypeTakeProfitMarket, OrderTypeTrailingStopMarket:
+	default:
+		return invalid("type", "unknown order type %q", p.Type)
+	}
+	if p.Side != SideTypeBuy && p.Side != SideTypeSell {
+		return invalid("side", "side must be BUY or SELL")
+	}
+	switch p.Type {
+	case OrderTypeLimit, OrderTypeStop, OrderTypeTakeProfit:
+		if p.Price.IsZero() && !priceMatch {
+			return invalid("price", "price or priceMatch is required for %s orders", p.Type)
+		}
+	}
+	switch p.Type {
+	case OrderTypeStop, OrderTypeTakeProfit, OrderTypeStopMarket, OrderTypeTakeProfitMarket:
+		if p.StopPrice.IsZero() {
+			return invalid("stopPrice", "stopPrice is required for %s orders", p.Type)
+		}
+	}
+
+	// closePosition closes the whole position, only STOP_MARKET and TAKE_PROFIT_MARKET
+	if opts.ClosePosition {
+		if p.Type != OrderTypeStopMarket && p.Type != OrderTypeTakeProfitMarket {
+			return invalid("closePosition", "not supported by %s orders", p.Type)
+		}
+		if !p.Quantity.IsZero() {
+			return invalid("closePosition", "can not be sent with quantity")
+		}
+		if p.ReduceOnly {
+			return invalid("closePosition", "can not be sent with reduceOnly")
+		}
+	} else if p.Quantity.IsZero() {
+		return invalid("quantity", "quantity is required for %s orders", p.Type)
+	}
+
+	if opts.WorkingType != "" && !isConditionalType(p.Type) {
+		return invalid("workingType", "not supported by %s orders", p.Type)
+	}
+	if opts.PriceProtect && !isConditionalType(p.Type) {
+		return invalid("priceProtect", "not supported by %s orders", p.Type)
+	}
+
+	if p.Type == OrderTypeLimit && opts.TimeInForce == "" {
+		return invalid("timeInForce", "timeInForce is required for LIMIT orders")
+	}
+	if p.Type == OrderTypeMarket && opts.TimeInForce != "" {
+		return invalid("timeInForce", "not supported by MARKET orders")
+	}
+
+	if opts.TimeInForce == TimeInForceTypeGTD {
+		if opts.GoodTillDate < now+minGoodTillDateDelay.Milliseconds() {
+			return invalid("goodTillDate", "must be at least %s ahead of the server time", minGoodTillDateDelay)
+		}
+		if opts.GoodTillDate > maxGoodTillDate {
+			return invalid("goodTillDate", "must be before %d", maxGoodTillDate)
+		}
+	} else if opts.GoodTillDate > 0 {
+		return invalid("goodTillDate", "needs timeInForce GTD")
+	}
+
+	if opts.SelfTradePreventionMode != "" && opts.SelfTradePreventionMode != SelfTradePreventionModeNone {
+		switch opts.TimeInForce {
+		case TimeInForceTypeGTC, TimeInForceTypeIOC, TimeInForceTypeGTD:
+		default:
+			return invalid("selfTradePreventionMode", "needs timeInForce GTC, IOC or GTD")
+		}
+	}
+
+	if priceMatch {
+		if p.Type != OrderTypeLimit && p.Type != OrderTypeStop && p.Type != OrderTypeTakeProfit {
+			return invalid("priceMatch", "not supported by %s orders", p.Type)
+		}
+		if !p.Price.IsZero() {
+			return invalid("priceMatch", "can not be sent with price")
+		}
+	}
+
+	if p.Type == OrderTypeTrailingStopMarket {
+		if opts.CallbackRate.LessThan(minCallbackRate) || opts.CallbackRate.GreaterThan(maxCallbackRate) {
+			return invalid("callbackRate", "%s is outside %s to %s", opts.CallbackRate, minCallbackRate, maxCallbackRate)
+		}
+		if !opts.CallbackRate.IsMultipleOf(callbackStep) {
+			return invalid("callbackRate", "%s is not a multiple of %s", opts.CallbackRate, callbackStep)
+		}
+	} else if !opts.ActivationPrice.IsZero() || !opts.CallbackRate.IsZero() {
+		return invalid("callbackRate", "activationPrice and callbackRate are only supported by TRAILING_STOP_MARKET orders")
+	}
+	return nil
+}
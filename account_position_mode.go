@@ -0,0 +1,119 @@
+package binance
+
+/* one-way and hedge position modes */
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+type jsonPositionMode struct {
+	DualSidePosition bool `json:"dualSidePosition"`
+}
+
+func (s *AccountService) GetPositionMode() (PositionMode, error) {
+	return s.GetPositionModeContext(context.Background())
+}
+
+// GetPositionModeContext reads the position mode of the account and caches it
+// on the client, orders are then checked against it before sending
+func (s *AccountService) GetPositionModeContext(ctx context.Context) (PositionMode, error) {
+	req := request{
+		method:   http.MethodGet,
+		endpoint: endPointPositionSide,
+		secType:  secTypeSigned,
+	}
+
+	data, err := s.c.callAPI(ctx, &req)
+	if err != nil {
+		return "", err
+	}
+
+	var res jsonPositionMode
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		s.c.logger.Error("error in parsing position mode json", "err", err, "data", string(data))
+		return "", err
+	}
+	mode := PositionModeOneWay
+	if res.DualSidePosition {
+		mode = PositionModeHedge
+	}
+	s.c.positionMode.Store(mode)
+	return mode, nil
+}
+
+func (s *AccountService) SetPositionMode(mode PositionMode) error {
+	return s.SetPositionModeContext(context.Background(), mode)
+}
+
+// SetPositionModeContext switches between one-way and hedge mode, binance
+// refuses to switch while there are open orders or positions. setting the
+// current mode is not an error
+func (s *AccountService) SetPositionModeContext(ctx context.Context, mode PositionMode) error {
+	req := request{
+		method:   http.MethodPost,
+		endpoint: endPointPositionSide,
+		secType:  secTypeSigned,
+		weight:   1,
+	}
+	req.setParam(key_DUAL_SIDE_POSITION, strconv.FormatBool(mode == PositionModeHedge))
+
+	_, err := s.c.callAPI(ctx, &req)
+	if err != nil && !errors.Is(err, ErrNoNeedToChangePositionSide) {
+		return err
+	}
+	s.c.positionMode.Store(mode)
+	return nil
+}
+
+// PositionMode returns the cached position mode, empty until it is read or set
+func (c *Client) PositionMode() PositionMode {
+	mode, _ := c.positionMode.Load().(PositionMode)
+	return mode
+}
+
+// checkPositionSide rejects orders whose position side does not fit the
+// position mode. LONG and SHORT are hedge mode sides, so reduceOnly is
+// rejected with them even when the mode has not been read yet
+func (c *Client) checkPositionSide(symbol string, p OrderParams, opts orderOptions) error {
+	invalid := func(field, reason string) error {
+		return &OrderValidationError{Symbol: symbol, Field: field, Reason: reason}
+	}
+
+	hedgeSide := opts.PositionSide == PositionSideTypeLong || opts.PositionSide == PositionSideTypeShort
+	switch c.PositionMode() {
+	case PositionModeHedge:
+		if !hedgeSide {
+			return invalid("positionSide", "positionSide must be LONG or SHORT in hedge mode")
+		}
+	case PositionModeOneWay:
+		if hedgeSide {
+			return invalid("positionSide", "positionSide must be BOTH or empty in one-way mode")
+		}
+	}
+	if hedgeSide && p.ReduceOnly {
+		return invalid("reduceOnly", "reduceOnly can not be sent in hedge mode, the position side sets the direction")
+	}
+	return nil
+}
+
+// PositionsByKey indexes positions by symbol and position side
+func PositionsByKey(positions []*Position) map[PositionKey]*Position {
+	m := make(map[PositionKey]*Position, len(positions))
+	for _, p := range positions {
+		m[p.Key()] = p
+	}
+	return m
+}
+
+// PositionsByKey indexes the positions of the account by symbol and position side
+func (a *Account) PositionsByKey() map[PositionKey]*AccountPosition {
+	m := make(map[PositionKey]*AccountPosition, len(a.Positions))
+	for _, p := range a.Positions {
+		m[p.Key()] = p
+	}
+	return m
+}
@@ -1,6 +1,6 @@
 package binance
 
-import "fmt"
+import "context"
 
 type AccountService struct {
 	c        *Client
@@ -11,107 +11,166 @@ func (c *Client) NewAccountService() *AccountService {
 	return &AccountService{c: c}
 }
 
-func (s *AccountService) GetBalances() []CoinBalance {
-	return s.getBalances()
+func (s *AccountService) GetBalances() ([]CoinBalance, error) {
+	return s.GetBalancesContext(context.Background())
 }
 
-func (s *AccountService) SetLeverage(symbol string, leverage int) bool {
-	return s.updateLeverage(symbol, leverage)
+func (s *AccountService) GetBalancesContext(ctx context.Context) ([]CoinBalance, error) {
+	return s.getBalances(ctx)
 }
 
-func (s *AccountService) SetMarginType(symbol string, marginType MarginType) bool {
-	return s.updateMarginType(symbol, marginType)
+func (s *AccountService) SetLeverage(symbol string, leverage int) (*LeverageResult, error) {
+	return s.SetLeverageContext(context.Background(), symbol, leverage)
 }
 
-func (s *AccountService) PlaceLimitOrder(info *InfoSymbol, order *LimitOrder) (*OrderResponse, error) {
+// SetLeverageContext sets the initial leverage of a symbol, setting the
+// current leverage again is not an error
+func (s *AccountService) SetLeverageContext(ctx context.Context, symbol string, leverage int) (*LeverageResult, error) {
+	return s.updateLeverage(ctx, symbol, leverage)
+}
 
-	orderService := OrderService{
-		c:           s.c,
-		Symbol:      order.Symbol,
-		Side:        order.Side,
-		OrderType:   OrderTypeLimit,
-		TimeInForce: order.TimeInForce,
-		Quantity:    fmt.Sprintf("%.5f", order.Quantity),
-		Price:       fmt.Sprintf(fmt.Sprintf("%%.%df", info.PricePrecision), order.Price),
+func (s *AccountService) SetMarginType(symbol string, marginType MarginType) (*MarginTypeResult, error) {
+	return s.SetMarginTypeContext(context.Background(), symbol, marginType)
+}
+
+// SetMarginTypeContext switches a symbol between ISOLATED and CROSSED margin,
+// binance answers -4046 when the symbol already has the margin type, which
+// is returned as success with Changed false
+func (s *AccountService) SetMarginTypeContext(ctx context.Context, symbol string, marginType MarginType) (*MarginTypeResult, error) {
+	return s.updateMarginType(ctx, symbol, marginType)
+}
+
+// newOrderService checks the order against the symbol filters and returns an
+// order with its prices and quantity formatted for the api. when info is nil
+// the symbol is looked up in the client's symbol registry
+func (s *AccountService) newOrderService(ctx context.Context, info *InfoSymbol, order Order) (*OrderService, error) {
+	symbol := order.orderSymbol()
+	if info == nil {
+		var err error
+		info, err = s.c.registry.SymbolContext(ctx, symbol)
+		if err != nil {
+			return nil, err
+		}
+	}
+	params := order.orderParams()
+	opts := order.orderOptions()
+	err := validateOrder(symbol, params, opts, s.c.timestamp())
+	if err == nil {
+		err = s.c.checkPositionSide(symbol, params, opts)
+	}
+	if err == nil {
+		params, err = info.NormalizeOrder(params)
 	}
-	return orderService.placeOrder()
+	if err != nil {
+		s.c.logger.Warn("error in validating order", "err", err, "symbol", symbol)
+		return nil, err
+	}
+	return &OrderService{
+		c:                       s.c,
+		Symbol:                  symbol,
+		Side:                    params.Side,
+		OrderType:               params.Type,
+		Quantity:                formatDecimal(params.Quantity),
+		Price:                   formatDecimal(params.Price),
+		StopPrice:               formatDecimal(params.StopPrice),
+		ReduceOnly:              params.ReduceOnly,
+		PositionSide:            opts.PositionSide,
+		TimeInForce:             opts.TimeInForce,
+		GoodTillDate:            opts.GoodTillDate,
+		SelfTradePreventionMode: opts.SelfTradePreventionMode,
+		PriceMatch:              opts.PriceMatch,
+		ClosePosition:           opts.ClosePosition,
+		WorkingType:             opts.WorkingType,
+		PriceProtect:            opts.PriceProtect,
+		ActivationPrice:         formatDecimal(info.roundPrice(opts.ActivationPrice)),
+		CallbackRate:            formatDecimal(opts.CallbackRate),
+		NewClientOrderId:        opts.NewClientOrderId,
+		NewOrderRespType:        opts.NewOrderRespType,
+	}, nil
 }
 
-func (s *AccountService) PlaceMarketOrder(info *InfoSymbol, order *MarketOrder) (*OrderResponse, error) {
-	orderService := OrderService{
-		c:         s.c,
-		Symbol:    order.Symbol,
-		Side:      order.Side,
-		OrderType: OrderTypeMarket,
-		Quantity:  fmt.Sprintf("%f", order.Quantity),
+func (s *AccountService) placeOrder(ctx context.Context, info *InfoSymbol, order Order) (*OrderResponse, error) {
+	orderService, err := s.newOrderService(ctx, info, order)
+	if err != nil {
+		return nil, err
 	}
-	return orderService.placeOrder()
+	return orderService.placeOrder(ctx)
+}
+
+func (s *AccountService) PlaceLimitOrder(info *InfoSymbol, order *LimitOrder) (*OrderResponse, error) {
+	return s.PlaceLimitOrderContext(context.Background(), info, order)
+}
+
+func (s *AccountService) PlaceLimitOrderContext(ctx context.Context, info *InfoSymbol, order *LimitOrder) (*OrderResponse, error) {
+	return s.placeOrder(ctx, info, order)
+}
+
+func (s *AccountService) PlaceMarketOrder(info *InfoSymbol, order *MarketOrder) (*OrderResponse, error) {
+	return s.PlaceMarketOrderContext(context.Background(), info, order)
+}
+
+func (s *AccountService) PlaceMarketOrderContext(ctx context.Context, info *InfoSymbol, order *MarketOrder) (*OrderResponse, error) {
+	return s.placeOrder(ctx, info, order)
 }
 
 func (s *AccountService) PlaceStopOrder(info *InfoSymbol, order *StopOrder) (*OrderResponse, error) {
-	orderService := OrderService{
-		c:          s.c,
-		Symbol:     order.Symbol,
-		Side:       order.Side,
-		OrderType:  OrderTypeStop,
-		Quantity:   fmt.Sprintf("%f", order.Quantity),
-		Price:      fmt.Sprintf(fmt.Sprintf("%%.%df", info.PricePrecision), order.Price),
-		StopPrice:  fmt.Sprintf(fmt.Sprintf("%%.%df", info.PricePrecision), order.StopPrice),
-		ReduceOnly: order.ReduceOnly,
-	}
-	return orderService.placeOrder()
+	return s.PlaceStopOrderContext(context.Background(), info, order)
+}
+
+func (s *AccountService) PlaceStopOrderContext(ctx context.Context, info *InfoSymbol, order *StopOrder) (*OrderResponse, error) {
+	return s.placeOrder(ctx, info, order)
 }
 
 func (s *AccountService) PlaceTakeProfitOrder(info *InfoSymbol, order *TakeProfitOrder) (*OrderResponse, error) {
-	orderService := OrderService{
-		c:          s.c,
-		Symbol:     order.Symbol,
-		Side:       order.Side,
-		OrderType:  OrderTypeTakeProfit,
-		Quantity:   fmt.Sprintf("%f", order.Quantity),
-		Price:      fmt.Sprintf(fmt.Sprintf("%%.%df", info.PricePrecision), order.Price),
-		StopPrice:  fmt.Sprintf(fmt.Sprintf("%%.%df", info.PricePrecision), order.StopPrice),
-		ReduceOnly: order.ReduceOnly,
-	}
-	return orderService.placeOrder()
+	return s.PlaceTakeProfitOrderContext(context.Background(), info, order)
+}
+
+func (s *AccountService) PlaceTakeProfitOrderContext(ctx context.Context, info *InfoSymbol, order *TakeProfitOrder) (*OrderResponse, error) {
+	return s.placeOrder(ctx, info, order)
 }
 
 func (s *AccountService) PlaceStopMarketOrder(info *InfoSymbol, order *StopMarketOrder) (*OrderResponse, error) {
-	orderService := OrderService{
-		c:          s.c,
-		Symbol:     order.Symbol,
-		Side:       order.Side,
-		OrderType:  OrderTypeStopMarket,
-		Quantity:   fmt.Sprintf(fmt.Sprintf("%%.%df", info.QuantityPrecision), order.Quantity),
-		StopPrice:  fmt.Sprintf(fmt.Sprintf("%%.%df", info.PricePrecision), order.StopPrice),
-		ReduceOnly: order.ReduceOnly,
-	}
-	return orderService.placeOrder()
+	return s.PlaceStopMarketOrderContext(context.Background(), info, order)
+}
+
+func (s *AccountService) PlaceStopMarketOrderContext(ctx context.Context, info *InfoSymbol, order *StopMarketOrder) (*OrderResponse, error) {
+	return s.placeOrder(ctx, info, order)
 }
 
 func (s *AccountService) PlaceTakeProfitMarketOrder(info *InfoSymbol, order *TakeProfitMarketOrder) (*OrderResponse, error) {
-	orderService := OrderService{
-		c:          s.c,
-		Symbol:     order.Symbol,
-		Side:       order.Side,
-		OrderType:  OrderTypeTakeProfitMarket,
-		Quantity:   fmt.Sprintf(fmt.Sprintf("%%.%df", info.QuantityPrecision), order.Quantity),
-		StopPrice:  fmt.Sprintf(fmt.Sprintf("%%.%df", info.PricePrecision), order.StopPrice),
-		ReduceOnly: order.ReduceOnly,
-	}
-	return orderService.placeOrder()
+	return s.PlaceTakeProfitMarketOrderContext(context.Background(), info, order)
+}
+
+func (s *AccountService) PlaceTakeProfitMarketOrderContext(ctx context.Context, info *InfoSymbol, order *TakeProfitMarketOrder) (*OrderResponse, error) {
+	return s.placeOrder(ctx, info, order)
+}
+
+func (s *AccountService) PlaceTrailingStopMarketOrder(info *InfoSymbol, order *TrailingStopMarketOrder) (*OrderResponse, error) {
+	return s.PlaceTrailingStopMarketOrderContext(context.Background(), info, order)
+}
+
+func (s *AccountService) PlaceTrailingStopMarketOrderContext(ctx context.Context, info *InfoSymbol, order *TrailingStopMarketOrder) (*OrderResponse, error) {
+	return s.placeOrder(ctx, info, order)
 }
 
 func (s *AccountService) CancelOrder(symbol string, orderId int64) (*OrderResponse, error) {
+	return s.CancelOrderContext(context.Background(), symbol, orderId)
+}
+
+func (s *AccountService) CancelOrderContext(ctx context.Context, symbol string, orderId int64) (*OrderResponse, error) {
 	orderService := OrderService{
 		c: s.c,
 	}
-	return orderService.cancelOrder(symbol, orderId)
+	return orderService.cancelOrder(ctx, symbol, orderId)
+}
+
+func (s *AccountService) CancelAllOpenOrders(symbol string) (bool, error) {
+	return s.CancelAllOpenOrdersContext(context.Background(), symbol)
 }
 
-func (s *AccountService) CancelAllOpenOrders(symbol string) bool {
+func (s *AccountService) CancelAllOpenOrdersContext(ctx context.Context, symbol string) (bool, error) {
 	orderService := OrderService{
 		c: s.c,
 	}
-	return orderService.cancelAllOpenOrders(symbol)
+	return orderService.cancelAllOpenOrders(ctx, symbol)
 }
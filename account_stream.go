@@ -1,85 +1,113 @@
 package binance
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"log"
-
-	"github.com/gorilla/websocket"
+	"sync"
+	"time"
 )
 
 /* stream to get updates on orders, positions etc.*/
 
+// the listen key expires 60 minutes after it was last extended
+const listenKeyKeepalive = 30 * time.Minute
+
 type AccountStream struct {
-	c         *Client
-	listenKey string
-	out       chan interface{}
+	c   *Client
+	out chan interface{}
+	wss *WebSocketStream
 
-	isActive   bool
-	wsConn     *websocket.Conn
-	wsOpenTime int64
-	timeout    int64
+	mu        sync.Mutex
+	listenKey string
 }
 
 func (c *Client) NewAccountStream() *AccountStream {
-	return &AccountStream{
-		c:       c,
-		out:     make(chan interface{}),
-		timeout: 30 * 60 * 1000, // refresh ws every 30 minutes
+	s := &AccountStream{
+		c:   c,
+		out: make(chan interface{}),
+		wss: newWebSocketStream("", c.logger),
 	}
+	s.wss.urlFunc = s.streamURL
+	return s
 }
 
 func (s *AccountStream) Start() <-chan interface{} {
-	s.isActive = true
-	go s.startStream()
+	return s.StartContext(context.Background())
+}
+
+// StartContext starts the stream, the out channel is closed once ctx is cancelled
+func (s *AccountStream) StartContext(ctx context.Context) <-chan interface{} {
+	ctx = s.wss.start(ctx)
+	go s.keepListenKey(ctx)
+	go s.startStream(ctx)
 	return s.out
 }
 
 func (s *AccountStream) Stop() {
-	s.isActive = false
+	s.wss.stop()
 }
 
-func (s *AccountStream) getNextMessage() ([]byte, error) {
-	if !s.isActive {
-		return nil, fmt.Errorf("account stream is inactive")
+// Events reports reconnects of the stream, it is closed with the out channel
+func (s *AccountStream) Events() <-chan StreamEvent {
+	return s.wss.events
+}
+
+// streamURL gets a listen key on every connect, binance returns the active
+// key if there is one and a new key once it has expired
+func (s *AccountStream) streamURL(ctx context.Context) (string, error) {
+	listenKey, err := s.getListenKey(ctx)
+	if err != nil {
+		s.c.logger.Error("error in getting listen key", "err", err)
+		return "", err
 	}
+	s.setListenKey(listenKey)
+	return fmt.Sprintf("%s/%s", s.c.wsBaseURL, listenKey), nil
+}
 
-	if s.wsOpenTime == 0 || (CurrentTimestamp()-s.wsOpenTime) > s.timeout {
-		if s.wsConn != nil {
-			s.wsConn.Close()
-		}
-		listenKey := s.getListenKey()
-		if listenKey == "" {
-			log.Println("error invalid listen key, closing account stream")
-			return nil, fmt.Errorf("wstream error")
-		}
-		s.listenKey = listenKey
-		url := fmt.Sprintf("%s/%s", baseWsMainURL, s.listenKey)
-		log.Println("opening new wstream: " + url)
-		c, _, err := websocket.DefaultDialer.Dial(url, nil)
-		if err != nil {
-			log.Println("error in creating account wstream : ", err)
-			return nil, err
-		}
-		s.wsConn = c
-		s.wsOpenTime = CurrentTimestamp()
+// setListenKey stores the key and reports whether it changed
+func (s *AccountStream) setListenKey(listenKey string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if listenKey == s.listenKey {
+		return false
 	}
-	_, msg, err := s.wsConn.ReadMessage()
-	if err != nil {
-		log.Println("error in reading account wstream : ", err)
-		return nil, err
+	s.c.logger.removeSecret(s.listenKey)
+	s.c.logger.addSecret(listenKey)
+	s.listenKey = listenKey
+	return true
+}
+
+// keepListenKey extends the listen key while the stream runs and
+// reconnects when binance hands out a new one
+func (s *AccountStream) keepListenKey(ctx context.Context) {
+	ticker := time.NewTicker(listenKeyKeepalive)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			listenKey, err := s.getListenKey(ctx)
+			if err != nil {
+				s.c.logger.Error("error in extending listen key", "err", err)
+				continue
+			}
+			if s.setListenKey(listenKey) {
+				s.c.logger.Warn("listen key changed, reconnecting account wstream")
+				s.wss.drop()
+			}
+		}
 	}
-	return msg, err
 }
 
-func (s *AccountStream) startStream() {
+func (s *AccountStream) startStream(ctx context.Context) {
 	defer close(s.out)
+	defer s.wss.finish()
 	messageCount := 0
 	for {
-		msg, err := s.getNextMessage()
-		if s.c.debug {
-			log.Println("==> event : " + string(msg))
-		}
+		msg, err := s.wss.getNextMessage(ctx)
+		s.c.logger.Debug("account event", "data", string(msg))
 		if err != nil {
 			break
 		}
@@ -87,8 +115,12 @@ func (s *AccountStream) startStream() {
 		if event == nil {
 			continue
 		}
-		s.out <- event
-		messageCount += 1
+		select {
+		case s.out <- event:
+			messageCount += 1
+		case <-ctx.Done():
+			return
+		}
 	}
 }
 
@@ -97,14 +129,12 @@ func (s *AccountStream) parseResponse(data []byte) interface{} {
 	var event map[string]interface{}
 	err := json.Unmarshal(data, &event)
 	if err != nil {
-		log.Println("error in parsing account ws response : ", err, string(data))
+		s.c.logger.Error("error in parsing account ws response", "err", err, "data", string(data))
 		return nil
 	}
 
 	eventType := event["e"].(string)
-	if s.c.debug {
-		log.Println("==> eventType : ", eventType)
-	}
+	s.c.logger.Debug("account event type", "eventType", eventType)
 
 	if eventType == EVENT_MARGIN_CALL {
 		accountEvent = s.parseMarginCallEvent(data)
@@ -112,6 +142,9 @@ func (s *AccountStream) parseResponse(data []byte) interface{} {
 		accountEvent = s.parseAccountUpdateEvent(data)
 	} else if eventType == Event_ORDER_TRADE_UPDATE {
 		accountEvent = s.parseOrderTradeUpdateEvent(data)
+	} else if eventType == string(UserDataEventTypeListenKeyExpired) {
+		s.c.logger.Warn("listen key expired, reconnecting account wstream")
+		s.wss.drop()
 	}
 
 	return accountEvent
@@ -119,14 +152,14 @@ func (s *AccountStream) parseResponse(data []byte) interface{} {
 
 // margin call event
 type jsonMCPosition struct {
-	Symbol            string `json:"s"`
-	PositionSide      string `json:"ps"`
-	PositionAmount    string `json:"pa"`
-	MarginType        string `json:"mt"`
-	IsolatedWallet    string `json:"iw"`
-	MarketPrice       string `json:"mp"`
-	UnrealizedPnL     string `json:"up"`
-	MaintenanceMargin string `json:"mm"`
+	Symbol            string           `json:"s"`
+	PositionSide      PositionSideType `json:"ps"`
+	PositionAmount    string           `json:"pa"`
+	MarginType        string           `json:"mt"`
+	IsolatedWallet    string           `json:"iw"`
+	MarketPrice       string           `json:"mp"`
+	UnrealizedPnL     string           `json:"up"`
+	MaintenanceMargin string           `json:"mm"`
 }
 
 type jsonMarginCallEvent struct {
@@ -140,7 +173,7 @@ func (s *AccountStream) parseMarginCallEvent(data []byte) *MarginCallEvent {
 	var event jsonMarginCallEvent
 	err := json.Unmarshal(data, &event)
 	if err != nil {
-		log.Println("error in parsing margin call event : ", err, string(data))
+		s.c.logger.Error("error in parsing margin call event", "err", err, "data", string(data))
 		return nil
 	}
 	positions := make([]MarginPosition, 0)
@@ -148,19 +181,19 @@ func (s *AccountStream) parseMarginCallEvent(data []byte) *MarginCallEvent {
 		position := MarginPosition{
 			Symbol:            p.Symbol,
 			PositionSide:      p.PositionSide,
-			PositionAmount:    parseFloat(p.PositionAmount),
+			PositionAmount:    parseDecimal(p.PositionAmount),
 			MarginType:        p.MarginType,
-			IsolatedWallet:    parseFloat(p.IsolatedWallet),
-			MarketPrice:       parseFloat(p.MarketPrice),
-			UnrealizedPnL:     parseFloat(p.UnrealizedPnL),
-			MaintenanceMargin: parseFloat(p.MaintenanceMargin),
+			IsolatedWallet:    parseDecimal(p.IsolatedWallet),
+			MarketPrice:       parseDecimal(p.MarketPrice),
+			UnrealizedPnL:     parseDecimal(p.UnrealizedPnL),
+			MaintenanceMargin: parseDecimal(p.MaintenanceMargin),
 		}
 		positions = append(positions, position)
 	}
 	return &MarginCallEvent{
 		Event:              event.Event,
 		EventTime:          event.EventTime,
-		CrossWalletBalance: parseFloat(event.CrossWalletBalance),
+		CrossWalletBalance: parseDecimal(event.CrossWalletBalance),
 		Positions:          positions,
 	}
 }
@@ -174,14 +207,14 @@ type jsonACBalance struct {
 }
 
 type jsonACPosition struct {
-	Symbol         string `json:"s"`
-	PositionAmount string `json:"pa"`
-	EntryPrice     string `json:"ep"`
-	Accumulated    string `json:"cr"`
-	UnrealizedPnL  string `json:"up"`
-	MarginType     string `json:"mt"`
-	IsolatedWallet string `json:"iw"`
-	PositionSide   string `json:"ps"`
+	Symbol         string           `json:"s"`
+	PositionAmount string           `json:"pa"`
+	EntryPrice     string           `json:"ep"`
+	Accumulated    string           `json:"cr"`
+	UnrealizedPnL  string           `json:"up"`
+	MarginType     string           `json:"mt"`
+	IsolatedWallet string           `json:"iw"`
+	PositionSide   PositionSideType `json:"ps"`
 }
 
 type jsonACData struct {
@@ -201,16 +234,16 @@ func (s *AccountStream) parseAccountUpdateEvent(data []byte) *AccountUpdateEvent
 	event := new(jsonAccountUpdateEvent)
 	err := json.Unmarshal(data, event)
 	if err != nil {
-		log.Println("error in parsing account update event : ", err, string(data))
+		s.c.logger.Error("error in parsing account update event", "err", err, "data", string(data))
 		return nil
 	}
 	balances := make([]AccountUpdateBalance, 0)
 	for _, b := range event.UpdateData.Balances {
 		balance := AccountUpdateBalance{
 			Asset:              b.Asset,
-			WalletBalance:      parseFloat(b.WalletBalance),
-			CrossWalletBalance: parseFloat(b.CrossWalletBalance),
-			BalanceChange:      parseFloat(b.CrossWalletBalance),
+			WalletBalance:      parseDecimal(b.WalletBalance),
+			CrossWalletBalance: parseDecimal(b.CrossWalletBalance),
+			BalanceChange:      parseDecimal(b.BalanceChange),
 		}
 		balances = append(balances, balance)
 	}
@@ -219,12 +252,12 @@ func (s *AccountStream) parseAccountUpdateEvent(data []byte) *AccountUpdateEvent
 	for _, p := range event.UpdateData.Positions {
 		position := AccountUpdatePosition{
 			Symbol:         p.Symbol,
-			PositionAmount: parseFloat(p.PositionAmount),
-			EntryPrice:     parseFloat(p.EntryPrice),
-			Accumulated:    parseFloat(p.Accumulated),
-			UnrealizedPnL:  parseFloat(p.UnrealizedPnL),
+			PositionAmount: parseDecimal(p.PositionAmount),
+			EntryPrice:     parseDecimal(p.EntryPrice),
+			Accumulated:    parseDecimal(p.Accumulated),
+			UnrealizedPnL:  parseDecimal(p.UnrealizedPnL),
 			MarginType:     p.MarginType,
-			IsolatedWallet: parseFloat(p.IsolatedWallet),
+			IsolatedWallet: parseDecimal(p.IsolatedWallet),
 			PositionSide:   p.PositionSide,
 		}
 		positions = append(positions, position)
@@ -244,36 +277,36 @@ func (s *AccountStream) parseAccountUpdateEvent(data []byte) *AccountUpdateEvent
 
 // order trade update events
 type jsonOrderTradeData struct {
-	Symbol               string `json:"s"`
-	ClientOrderId        string `json:"c"`
-	OrderSide            string `json:"S"`
-	OrderType            string `json:"o"`
-	TimeInForce          string `json:"f"`
-	Quantity             string `json:"q"`
-	Price                string `json:"p"`
-	AveragePrice         string `json:"ap"`
-	StopPrice            string `json:"sp"`
-	ExectutionType       string `json:"x"`
-	OrderStatus          string `json:"X"`
-	OrderId              int64  `json:"i"`
-	LastFilledQuantity   string `json:"l"`
-	AccumulatedQuantity  string `json:"z"`
-	LastFilledPrice      string `json:"L"`
-	CommissionAsset      string `json:"N"`
-	Commission           string `json:"n"`
-	TradeTime            int64  `json:"T"`
-	TradeId              int64  `json:"t"`
-	BidsNotional         string `json:"b"`
-	AskNotional          string `json:"a"`
-	IsMakerSide          bool   `json:"m"`
-	IsReduceOnly         bool   `json:"R"`
-	StopPriceWorkingType string `json:"wt"`
-	OringalOrderType     string `json:"ot"`
-	PositionSide         string `json:"ps"`
-	IsCloseAll           bool   `json:"cp"`
-	ActivationPrice      string `json:"AP"`
-	CallbackRate         string `json:"cr"`
-	RealizedProfit       string `json:"rp"`
+	Symbol               string           `json:"s"`
+	ClientOrderId        string           `json:"c"`
+	OrderSide            string           `json:"S"`
+	OrderType            string           `json:"o"`
+	TimeInForce          string           `json:"f"`
+	Quantity             string           `json:"q"`
+	Price                string           `json:"p"`
+	AveragePrice         string           `json:"ap"`
+	StopPrice            string           `json:"sp"`
+	ExectutionType       string           `json:"x"`
+	OrderStatus          string           `json:"X"`
+	OrderId              int64            `json:"i"`
+	LastFilledQuantity   string           `json:"l"`
+	AccumulatedQuantity  string           `json:"z"`
+	LastFilledPrice      string           `json:"L"`
+	CommissionAsset      string           `json:"N"`
+	Commission           string           `json:"n"`
+	TradeTime            int64            `json:"T"`
+	TradeId              int64            `json:"t"`
+	BidsNotional         string           `json:"b"`
+	AskNotional          string           `json:"a"`
+	IsMakerSide          bool             `json:"m"`
+	IsReduceOnly         bool             `json:"R"`
+	StopPriceWorkingType string           `json:"wt"`
+	OringalOrderType     string           `json:"ot"`
+	PositionSide         PositionSideType `json:"ps"`
+	IsCloseAll           bool             `json:"cp"`
+	ActivationPrice      string           `json:"AP"`
+	CallbackRate         string           `json:"cr"`
+	RealizedProfit       string           `json:"rp"`
 }
 
 type jsonOrderTradeUpdateEvent struct {
@@ -287,7 +320,7 @@ func (s *AccountStream) parseOrderTradeUpdateEvent(data []byte) *OrderTradeUpdat
 	event := new(jsonOrderTradeUpdateEvent)
 	err := json.Unmarshal(data, event)
 	if err != nil {
-		log.Println("error in parsing trade update event : ", err, string(data))
+		s.c.logger.Error("error in parsing trade update event", "err", err, "data", string(data))
 		return nil
 	}
 	order := event.OrderData
@@ -302,30 +335,30 @@ func (s *AccountStream) parseOrderTradeUpdateEvent(data []byte) *OrderTradeUpdat
 			OrderSide:            order.OrderSide,
 			OrderType:            order.OrderType,
 			TimeInForce:          order.TimeInForce,
-			Quantity:             parseFloat(order.Quantity),
-			Price:                parseFloat(order.Price),
-			AveragePrice:         parseFloat(order.AveragePrice),
-			StopPrice:            parseFloat(order.StopPrice),
+			Quantity:             parseDecimal(order.Quantity),
+			Price:                parseDecimal(order.Price),
+			AveragePrice:         parseDecimal(order.AveragePrice),
+			StopPrice:            parseDecimal(order.StopPrice),
 			ExectutionType:       order.ExectutionType,
 			OrderStatus:          order.OrderStatus,
 			OrderId:              order.OrderId,
-			LastFilledQuantity:   parseFloat(order.LastFilledQuantity),
-			LastFilledPrice:      parseFloat(order.LastFilledPrice),
+			LastFilledQuantity:   parseDecimal(order.LastFilledQuantity),
+			LastFilledPrice:      parseDecimal(order.LastFilledPrice),
 			CommissionAsset:      order.CommissionAsset,
-			Commission:           parseFloat(order.Commission),
+			Commission:           parseDecimal(order.Commission),
 			TradeTime:            order.TradeTime,
 			TradeId:              order.TradeId,
-			BidsNotional:         parseFloat(order.BidsNotional),
-			AskNotional:          parseFloat(order.AskNotional),
+			BidsNotional:         parseDecimal(order.BidsNotional),
+			AskNotional:          parseDecimal(order.AskNotional),
 			IsMakerSide:          order.IsMakerSide,
 			IsReduceOnly:         order.IsReduceOnly,
 			StopPriceWorkingType: order.StopPriceWorkingType,
 			OringalOrderType:     order.OringalOrderType,
 			PositionSide:         order.PositionSide,
 			IsCloseAll:           order.IsCloseAll,
-			ActivationPrice:      parseFloat(order.ActivationPrice),
-			CallbackRate:         parseFloat(order.CallbackRate),
-			RealizedProfit:       parseFloat(order.RealizedProfit),
+			ActivationPrice:      parseDecimal(order.ActivationPrice),
+			CallbackRate:         parseDecimal(order.CallbackRate),
+			RealizedProfit:       parseDecimal(order.RealizedProfit),
 		},
 	}
 }
@@ -0,0 +1,155 @@
+package binance
+
+/* account trade list */
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+type jsonAccountTrade struct {
+	Buyer           bool             `json:"buyer"`
+	Commission      string           `json:"commission"`
+	CommissionAsset string           `json:"commissionAsset"`
+	Id              int64            `json:"id"`
+	Maker           bool             `json:"maker"`
+	OrderId         int64            `json:"orderId"`
+	Price           string           `json:"price"`
+	Quantity        string           `json:"qty"`
+	QuoteQuantity   string           `json:"quoteQty"`
+	RealizedPnl     string           `json:"realizedPnl"`
+	Side            SideType         `json:"side"`
+	PositionSide    PositionSideType `json:"positionSide"`
+	Symbol          string           `json:"symbol"`
+	Time            int64            `json:"time"`
+}
+
+// TradesQuery selects a single page of trades, FromId can not be combined
+// with StartTime and EndTime, which must be at most 7 days apart
+type TradesQuery struct {
+	Symbol    string
+	OrderId   int64
+	StartTime int64
+	EndTime   int64
+	FromId    int64
+	Limit     int // default 500, max 1000
+}
+
+func (s *AccountService) GetTrades(query *TradesQuery) ([]*AccountTrade, error) {
+	return s.GetTradesContext(context.Background(), query)
+}
+
+func (s *AccountService) GetTradesContext(ctx context.Context, query *TradesQuery) ([]*AccountTrade, error) {
+	req := request{
+		method:   http.MethodGet,
+		endpoint: endPointUserTrades,
+		secType:  secTypeSigned,
+	}
+	req.setParam(key_SYMBOL, query.Symbol)
+	if query.OrderId > 0 {
+		req.setParam(key_ORDER_ID, query.OrderId)
+	}
+	if query.StartTime > 0 {
+		req.setParam(key_STARTTIME, query.StartTime)
+	}
+	if query.EndTime > 0 {
+		req.setParam(key_ENDTIME, query.EndTime)
+	}
+	if query.FromId > 0 {
+		req.setParam(key_FROM_ID, query.FromId)
+	}
+	if query.Limit > 0 {
+		req.setParam(key_LIMIT, query.Limit)
+	}
+
+	data, err := s.c.callAPI(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	var resList []jsonAccountTrade
+	err = json.Unmarshal(data, &resList)
+	if err != nil {
+		s.c.logger.Error("error in parsing trades json", "err", err, "data", string(data))
+		return nil, err
+	}
+	trades := make([]*AccountTrade, 0, len(resList))
+	for _, res := range resList {
+		trades = append(trades, &AccountTrade{
+			Id:              res.Id,
+			OrderId:         res.OrderId,
+			Symbol:          res.Symbol,
+			Side:            res.Side,
+			PositionSide:    res.PositionSide,
+			Buyer:           res.Buyer,
+			Maker:           res.Maker,
+			Price:           parseDecimal(res.Price),
+			Quantity:        parseDecimal(res.Quantity),
+			QuoteQuantity:   parseDecimal(res.QuoteQuantity),
+			RealizedPnl:     parseDecimal(res.RealizedPnl),
+			Commission:      parseDecimal(res.Commission),
+			CommissionAsset: res.CommissionAsset,
+			Time:            res.Time,
+		})
+	}
+	return trades, nil
+}
+
+// TradesIterator pages through the trades of a symbol between two times. it
+// walks the range in 7 day windows until it finds trades, then follows the
+// trade ids so pages of 1000 trades are never cut short.
+type TradesIterator struct {
+	s      *AccountService
+	symbol string
+	pager  historyPager
+	page   []*AccountTrade
+}
+
+// NewTradesIterator iterates trades made between startTime and endTime
+// (milliseconds), endTime zero means now. a zero startTime or an endTime
+// before it fails with ErrInvalidTimeRange
+func (s *AccountService) NewTradesIterator(symbol string, startTime, endTime int64) *TradesIterator {
+	return &TradesIterator{
+		s:      s,
+		symbol: symbol,
+		pager:  newHistoryPager(startTime, endTime),
+	}
+}
+
+// Next fetches the next non empty page, it returns false when done or on error
+func (it *TradesIterator) Next(ctx context.Context) bool {
+	var trades []*AccountTrade
+	n, ok := it.pager.next(func(startTime, endTime, fromId int64) ([]historyKey, error) {
+		var err error
+		trades, err = it.s.GetTradesContext(ctx, &TradesQuery{
+			Symbol:    it.symbol,
+			FromId:    fromId,
+			StartTime: startTime,
+			EndTime:   endTime,
+			Limit:     maxHistoryLimit,
+		})
+		if err != nil {
+			return nil, err
+		}
+		keys := make([]historyKey, 0, len(trades))
+		for _, trade := range trades {
+			keys = append(keys, historyKey{Id: trade.Id, Time: trade.Time})
+		}
+		return keys, nil
+	})
+	if !ok {
+		it.page = nil
+		return false
+	}
+	it.page = trades[:n]
+	return true
+}
+
+// Page returns the trades fetched by the last call to Next
+func (it *TradesIterator) Page() []*AccountTrade {
+	return it.page
+}
+
+func (it *TradesIterator) Err() error {
+	return it.pager.err
+}
@@ -3,6 +3,8 @@ package binance
 import (
 	"log"
 	"net/http"
+	"sync/atomic"
+	"time"
 )
 
 const (
@@ -22,51 +24,92 @@ const (
 	endPoint24hrTicker       = "/fapi/v1/ticker/24hr"
 	endPointKlines           = "/fapi/v1/klines"
 	endPointContinuousKlines = "/fapi/v1/continuousKlines"
+	endPointDepth            = "/fapi/v1/depth"
 
 	// userdata
-	endPointBalance       = "/fapi/v2/balance"
-	endPointAccount       = "/fapi/v2/account"
-	endPointOrder         = "/fapi/v1/order"
-	endPointAllOpenOrders = "/fapi/v1/allOpenOrders"
-	endPointLeverage      = "/fapi/v1/leverage"
-	endPointMarginType    = "/fapi/v1/marginType"
-	endPointListenKey     = "/fapi/v1/listenKey"
+	endPointBalance         = "/fapi/v2/balance"
+	endPointAccount         = "/fapi/v2/account"
+	endPointOrder           = "/fapi/v1/order"
+	endPointOrderTest       = "/fapi/v1/order/test"
+	endPointAllOpenOrders   = "/fapi/v1/allOpenOrders"
+	endPointBatchOrders     = "/fapi/v1/batchOrders"
+	endPointOrderAmendment  = "/fapi/v1/orderAmendment"
+	endPointOpenOrder       = "/fapi/v1/openOrder"
+	endPointOpenOrders      = "/fapi/v1/openOrders"
+	endPointAllOrders       = "/fapi/v1/allOrders"
+	endPointUserTrades      = "/fapi/v1/userTrades"
+	endPointIncome          = "/fapi/v1/income"
+	endPointPositionRisk    = "/fapi/v2/positionRisk"
+	endPointLeverage        = "/fapi/v1/leverage"
+	endPointLeverageBracket = "/fapi/v1/leverageBracket"
+	endPointMarginType      = "/fapi/v1/marginType"
+	endPointMultiAssets     = "/fapi/v1/multiAssetsMargin"
+	endPointPositionMargin  = "/fapi/v1/positionMargin"
+	endPointMarginHistory   = "/fapi/v1/positionMargin/history"
+	endPointListenKey       = "/fapi/v1/listenKey"
+	endPointPositionSide    = "/fapi/v1/positionSide/dual"
 )
 
+const defaultRequestTimeout = 30 * time.Second
+
 type Client struct {
-	apiKey     string
-	secretKey  string
-	baseURL    string
-	debug      bool
-	weightUsed int
+	apiKey      string
+	signer      Signer
+	baseURL     string
+	wsBaseURL   string
+	wsStreamURL string // combined streams, <url><stream>/<stream>...
+	recvWindow  int64
+	userAgent   string
+	httpClient  *http.Client
+	logger      *redactingLogger
+	limiter     *RateLimiter
+	retryPolicy RetryPolicy
+	registry    *SymbolRegistry
+	timeOffset  int64 // server clock minus local clock in milliseconds
+
+	positionMode atomic.Value // PositionMode, set once read or changed
 }
 
-func NewClient(apiKey, secretKey string) *Client {
+func NewClient(apiKey, secretKey string, opts ...ClientOption) *Client {
 	c := &Client{
-		apiKey:    apiKey,
-		secretKey: secretKey,
-		baseURL:   baseApiMainURL,
+		apiKey:      apiKey,
+		signer:      NewHMACSigner(secretKey),
+		baseURL:     baseApiMainURL,
+		wsBaseURL:   baseWsMainURL,
+		wsStreamURL: baseCombinedMainURL,
+		logger:      newRedactingLogger(nopLogger{}),
+		limiter:     newRateLimiter(RateLimitPolicyBlock),
+		retryPolicy: DefaultRetryPolicy,
+	}
+	cfg := clientConfig{timeout: defaultRequestTimeout}
+	for _, opt := range opts {
+		opt(c, &cfg)
 	}
+	c.httpClient = cfg.buildHTTPClient()
+	c.registry = newSymbolRegistry(c)
+	c.logger.addSecret(apiKey)
 	return c
 }
 
 func (c *Client) UseTestNet() {
 	c.baseURL = baseApiTestnetURL
+	c.wsBaseURL = baseWsTestnetURL
+	c.wsStreamURL = baseCombinedTestnetURL
 }
 
+// DebugMode logs everything (secrets redacted) to the standard logger,
+// unless a logger was set with WithLogger
 func (c *Client) DebugMode() {
-	c.debug = true
+	if _, ok := c.logger.next.(nopLogger); ok {
+		c.logger.next = NewStdLogger(log.Default(), LogLevelDebug)
+	}
 }
 
 func (c *Client) PrintServerTime() {
-	req := request{
-		method:   http.MethodGet,
-		endpoint: endPointServerTime,
-	}
-
-	data, err := c.callAPI(&req)
+	serverTime, err := c.GetServerTime()
 	if err != nil {
-		log.Println("error in reading server time : ", err)
+		c.logger.Error("error in reading server time", "err", err)
+		return
 	}
-	log.Println("server time :", string(data))
+	c.logger.Info("server time", "serverTime", serverTime, "localTime", CurrentTimestamp())
 }
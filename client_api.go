@@ -1,9 +1,10 @@
 package binance
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"net/url"
 	"time"
@@ -25,6 +26,8 @@ type request struct {
 	secType    secType
 	header     http.Header
 	fullURL    string
+	weight     int64 // overrides endpointWeights when set
+	orders     int64 // number of orders placed by the request
 }
 
 func (r *request) setParam(key string, value interface{}) *request {
@@ -42,14 +45,17 @@ func (r *request) setParams(m map[string]interface{}) *request {
 	return r
 }
 
-func (c *Client) parseRequest(r *request) {
+func (c *Client) parseRequest(r *request) error {
 
 	fullURL := fmt.Sprintf("%s%s", c.baseURL, r.endpoint)
+	if r.recvWindow == 0 && r.secType == secTypeSigned {
+		r.recvWindow = c.recvWindow
+	}
 	if r.recvWindow > 0 {
 		r.setParam(key_RECVWINDOW, r.recvWindow)
 	}
 	if r.secType == secTypeSigned {
-		r.setParam(key_TIMESTAMP, CurrentTimestamp())
+		r.setParam(key_TIMESTAMP, c.timestamp())
 	}
 
 	queryString := r.query.Encode()
@@ -60,11 +66,18 @@ func (c *Client) parseRequest(r *request) {
 	if r.secType == secTypeAPIKey || r.secType == secTypeSigned {
 		header.Set(X_MBX_APIKEY, c.apiKey)
 	}
+	if c.userAgent != "" {
+		header.Set("User-Agent", c.userAgent)
+	}
 
 	// append signature
 	if r.secType == secTypeSigned {
+		signature, err := c.signer.Sign(queryString)
+		if err != nil {
+			return fmt.Errorf("error in signing request : %w", err)
+		}
 		v := url.Values{}
-		v.Set(key_SIGNATURE, computeSignature(queryString, c.secretKey))
+		v.Set(key_SIGNATURE, signature)
 		if queryString == "" {
 			queryString = v.Encode()
 		} else {
@@ -74,49 +87,120 @@ func (c *Client) parseRequest(r *request) {
 	if queryString != "" {
 		fullURL = fmt.Sprintf("%s?%s", fullURL, queryString)
 	}
-	log.Println("full url : " + fullURL)
 	r.fullURL = fullURL
 	r.header = header
+	return nil
+}
+
+// callAPI sends the request, retrying idempotent requests on transient
+// failures according to the client's retry policy
+func (c *Client) callAPI(ctx context.Context, r *request) ([]byte, error) {
+	retryable := r.isIdempotent()
+	resynced := false
+	maybePlaced := false // an earlier attempt of an order placement may have reached binance
+	for attempt := 1; ; attempt++ {
+		data, sent, err := c.doRequest(ctx, r)
+		if err == nil {
+			return data, nil
+		}
+
+		// the request was rejected before execution, safe to resend once with a corrected clock
+		if r.secType == secTypeSigned && !resynced && errors.Is(err, ErrTimestampOutsideRecvWindow) {
+			resynced = true
+			if _, syncErr := c.SyncTimeContext(ctx); syncErr == nil {
+				attempt--
+				continue
+			}
+		}
+		if errors.Is(err, ErrInvalidSymbol) {
+			c.registry.refreshAsync()
+		}
+		if r.isOrderPlacement() && sent && isStatusUnknown(err) {
+			maybePlaced = true
+		}
+		if !retryable || attempt >= c.retryPolicy.MaxAttempts || !c.retryPolicy.isRetryable(err) || ctx.Err() != nil {
+			return data, r.finalError(err, maybePlaced)
+		}
+
+		delay := c.retryPolicy.backoff(attempt)
+		c.logger.Warn("retrying api call", "method", r.method, "endpoint", r.endpoint, "delay", delay, "attempt", attempt+1, "maxAttempts", c.retryPolicy.MaxAttempts, "err", err)
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return data, r.finalError(err, maybePlaced)
+		}
+	}
+}
+
+// finalError reports an order placement as unknown once any attempt may have
+// reached binance, a retry is then rejected as a duplicate newClientOrderId
+// (or for any other reason) while the first attempt may be live
+func (r *request) finalError(err error, maybePlaced bool) error {
+	if !maybePlaced {
+		return err
+	}
+	return &OrderStatusUnknownError{
+		Symbol:        r.query.Get(key_SYMBOL),
+		ClientOrderId: r.query.Get(key_NEW_CLIENT_ORDER_ID),
+		Err:           err,
+	}
 }
 
-func (c *Client) callAPI(r *request) ([]byte, error) {
+// doRequest makes a single attempt of the request, sent reports whether the
+// request was handed to the http client, errors before that can not have
+// reached binance
+func (c *Client) doRequest(ctx context.Context, r *request) ([]byte, bool, error) {
 
-	if c.weightUsed > 1000 {
-		log.Println("error weight limit exceeded, sleeping for 1 min, weight used : ", c.weightUsed)
-		time.Sleep(1 * time.Minute)
+	weight := r.weight
+	if weight == 0 {
+		weight = endpointWeights[r.endpoint]
+	}
+	if weight == 0 {
+		weight = 1
+	}
+	if err := c.limiter.acquire(ctx, weight, r.orders); err != nil {
+		c.logger.Warn("error in acquiring rate limit", "err", err, "endpoint", r.endpoint, "weight", weight)
+		return []byte{}, false, err
 	}
 
-	c.parseRequest(r)
-	httpClient := &http.Client{}
-	req, _ := http.NewRequest(r.method, r.fullURL, nil)
+	if err := c.parseRequest(r); err != nil {
+		return []byte{}, false, err
+	}
+	req, err := http.NewRequestWithContext(ctx, r.method, r.fullURL, nil)
+	if err != nil {
+		return []byte{}, false, err
+	}
 
 	// call http api
 	req.Header = r.header
-	res, err := httpClient.Do(req)
+	start := time.Now()
+	res, err := c.httpClient.Do(req)
 	if err != nil {
-		log.Println("error in api call", err, r.fullURL)
-		return []byte{}, err
+		c.logger.Error("error in api call", "err", err, "method", r.method, "endpoint", r.endpoint, "latency", time.Since(start))
+		return []byte{}, true, err
 	}
 	defer res.Body.Close()
 
-	// update weight used
-	weightUsed := int(ParseInt(res.Header.Get("X-Mbx-Used-Weight-1m")))
-	if weightUsed > 0 {
-		c.weightUsed = weightUsed
-	}
+	// update rate limit usage
+	c.limiter.update(res)
 
 	// read api response
 	data, err := ioutil.ReadAll(res.Body)
 	if err != nil {
-		log.Println("error in reading api response : ", err)
-		return []byte{}, err
+		c.logger.Error("error in reading api response", "err", err, "endpoint", r.endpoint)
+		return []byte{}, true, err
 	}
 
 	// check for status code
-	if res.StatusCode != 200 {
-		log.Printf("error got invalid status code (%d) : %s\n", res.StatusCode, string(data))
-		return []byte{}, fmt.Errorf("invalid status code(%d) : %s", res.StatusCode, string(data))
+	if res.StatusCode != http.StatusOK {
+		apiErr := newAPIError(res, r.endpoint, data)
+		c.logger.Error("error in api call", "err", apiErr, "method", r.method, "endpoint", r.endpoint, "status", res.StatusCode, "code", apiErr.Code, "latency", time.Since(start))
+		return []byte{}, true, apiErr
 	}
 
-	return data, nil
+	c.logger.Debug("api call", "method", r.method, "endpoint", r.endpoint, "status", res.StatusCode, "weight", weight,
+		"usedWeight", res.Header.Get("X-Mbx-Used-Weight-1m"), "latency", time.Since(start))
+	return data, true, nil
 }
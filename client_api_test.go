@@ -0,0 +1,68 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newOrderRequest() *request {
+	req := &request{method: http.MethodPost, endpoint: endPointOrder, secType: secTypeSigned, orders: 1}
+	req.setParam(key_SYMBOL, "BTCUSDT")
+	req.setParam(key_NEW_CLIENT_ORDER_ID, "abc")
+	return req
+}
+
+func TestCallAPIOrderNotSent(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer srv.Close()
+	c := NewClient("k", "s", WithBaseURL(srv.URL))
+
+	// exhaust the order windows so acquire waits until the context ends
+	now := time.Now()
+	for _, b := range c.limiter.buckets {
+		b.roll(now)
+		if b.limitType == RateLimitTypeOrders {
+			b.used = b.limit
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := c.callAPI(ctx, newOrderRequest())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+	if errors.Is(err, ErrOrderStatusUnknown) {
+		t.Errorf("order that was never sent reported as unknown : %v", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 0 {
+		t.Errorf("server called %d times", n)
+	}
+}
+
+func TestCallAPIRetriedOrderUnknown(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code":-4116,"msg":"ClientOrderId is duplicated."}`))
+	}))
+	defer srv.Close()
+	c := NewClient("k", "s", WithBaseURL(srv.URL))
+
+	_, err := c.callAPI(context.Background(), newOrderRequest())
+	if !errors.Is(err, ErrOrderStatusUnknown) || !errors.Is(err, ErrDuplicateClientOrderId) {
+		t.Fatalf("err = %v, want unknown status wrapping the duplicate id error", err)
+	}
+}
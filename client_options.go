@@ -0,0 +1,121 @@
+package binance
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ClientOption configures a Client, see NewClient
+type ClientOption func(c *Client, cfg *clientConfig)
+
+// clientConfig holds the options which are only needed while building the client
+type clientConfig struct {
+	httpClient *http.Client
+	transport  http.RoundTripper
+	timeout    time.Duration
+}
+
+// buildHTTPClient returns the http client shared by every request, a
+// caller provided client is copied so that it is never mutated
+func (cfg *clientConfig) buildHTTPClient() *http.Client {
+	httpClient := &http.Client{}
+	if cfg.httpClient != nil {
+		hc := *cfg.httpClient
+		httpClient = &hc
+	}
+	if cfg.transport != nil {
+		httpClient.Transport = cfg.transport
+	}
+	if cfg.timeout > 0 {
+		httpClient.Timeout = cfg.timeout
+	}
+	return httpClient
+}
+
+// WithHTTPClient uses the given http client for every rest call
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client, cfg *clientConfig) {
+		cfg.httpClient = httpClient
+		if httpClient.Timeout > 0 {
+			cfg.timeout = httpClient.Timeout
+		}
+	}
+}
+
+// WithTransport sets the round tripper used by the http client (proxies, mocks etc.)
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(c *Client, cfg *clientConfig) {
+		cfg.transport = transport
+	}
+}
+
+// WithTimeout sets the timeout of each rest call, zero disables it
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client, cfg *clientConfig) {
+		cfg.timeout = timeout
+	}
+}
+
+// WithBaseURL overrides the rest api url, e.g. an httptest.Server url
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client, cfg *clientConfig) {
+		c.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithWsBaseURL overrides the websocket url, streams are opened at <url>/<stream>
+// and combined streams at <url without /ws>/stream?streams=
+func WithWsBaseURL(wsBaseURL string) ClientOption {
+	return func(c *Client, cfg *clientConfig) {
+		c.wsBaseURL = strings.TrimSuffix(wsBaseURL, "/")
+		c.wsStreamURL = strings.TrimSuffix(c.wsBaseURL, "/ws") + "/stream?streams="
+	}
+}
+
+// WithRecvWindow sets the recvWindow (in milliseconds) of signed requests
+// which don't set their own
+func WithRecvWindow(recvWindow int64) ClientOption {
+	return func(c *Client, cfg *clientConfig) {
+		c.recvWindow = recvWindow
+	}
+}
+
+// WithUserAgent sets the User-Agent header of every rest call
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client, cfg *clientConfig) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithLogger sets the logger used by the client and its streams, e.g. a
+// *slog.Logger or NewStdLogger. the client is silent without one.
+// api keys, signatures and listen keys are always redacted.
+func WithLogger(logger Logger) ClientOption {
+	return func(c *Client, cfg *clientConfig) {
+		c.logger.next = logger
+	}
+}
+
+// WithRateLimitPolicy sets whether requests wait for, or fail on, an exhausted rate limit window
+func WithRateLimitPolicy(policy RateLimitPolicy) ClientOption {
+	return func(c *Client, cfg *clientConfig) {
+		c.limiter.policy = policy
+	}
+}
+
+// WithRetryPolicy sets the retry policy of idempotent requests, use
+// RetryPolicy{} to disable retries
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client, cfg *clientConfig) {
+		c.retryPolicy = policy
+	}
+}
+
+// WithSigner signs requests with the given signer instead of the hmac secret key,
+// e.g. an Ed25519Signer or RSASigner for api keys registered with a public key
+func WithSigner(signer Signer) ClientOption {
+	return func(c *Client, cfg *clientConfig) {
+		c.signer = signer
+	}
+}
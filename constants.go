@@ -18,6 +18,11 @@ type ContractType string
 type UserDataEventType string
 type UserDataEventReasonType string
 type ForceOrderCloseType string
+type IncomeType string
+type PriceMatchType string
+type SelfTradePreventionMode string
+type PositionMode string
+type PositionMarginType int
 
 const (
 	SideTypeBuy  SideType = "BUY"
@@ -27,6 +32,12 @@ const (
 	PositionSideTypeLong  PositionSideType = "LONG"
 	PositionSideTypeShort PositionSideType = "SHORT"
 
+	PositionMarginTypeAdd    PositionMarginType = 1
+	PositionMarginTypeReduce PositionMarginType = 2
+
+	PositionModeOneWay PositionMode = "ONE_WAY" // a single BOTH position per symbol
+	PositionModeHedge  PositionMode = "HEDGE"   // separate LONG and SHORT positions per symbol
+
 	OrderTypeLimit              OrderType = "LIMIT"
 	OrderTypeMarket             OrderType = "MARKET"
 	OrderTypeStop               OrderType = "STOP"
@@ -39,6 +50,7 @@ const (
 	TimeInForceTypeIOC TimeInForceType = "IOC" // Immediate or Cancel
 	TimeInForceTypeFOK TimeInForceType = "FOK" // Fill or Kill
 	TimeInForceTypeGTX TimeInForceType = "GTX" // Good Till Crossing (Post Only)
+	TimeInForceTypeGTD TimeInForceType = "GTD" // Good Till Date
 
 	NewOrderRespTypeACK    NewOrderRespType = "ACK"
 	NewOrderRespTypeRESULT NewOrderRespType = "RESULT"
@@ -114,6 +126,41 @@ const (
 	ForceOrderCloseTypeLiquidation ForceOrderCloseType = "LIQUIDATION"
 	ForceOrderCloseTypeADL         ForceOrderCloseType = "ADL"
 
+	PriceMatchTypeNone       PriceMatchType = "NONE"
+	PriceMatchTypeOpponent   PriceMatchType = "OPPONENT"    // best price on the other side
+	PriceMatchTypeOpponent5  PriceMatchType = "OPPONENT_5"  // 5th best price on the other side
+	PriceMatchTypeOpponent10 PriceMatchType = "OPPONENT_10" // 10th best price on the other side
+	PriceMatchTypeOpponent20 PriceMatchType = "OPPONENT_20" // 20th best price on the other side
+	PriceMatchTypeQueue      PriceMatchType = "QUEUE"       // best price on the same side
+	PriceMatchTypeQueue5     PriceMatchType = "QUEUE_5"     // 5th best price on the same side
+	PriceMatchTypeQueue10    PriceMatchType = "QUEUE_10"    // 10th best price on the same side
+	PriceMatchTypeQueue20    PriceMatchType = "QUEUE_20"    // 20th best price on the same side
+
+	SelfTradePreventionModeNone        SelfTradePreventionMode = "NONE"
+	SelfTradePreventionModeExpireTaker SelfTradePreventionMode = "EXPIRE_TAKER"
+	SelfTradePreventionModeExpireMaker SelfTradePreventionMode = "EXPIRE_MAKER"
+	SelfTradePreventionModeExpireBoth  SelfTradePreventionMode = "EXPIRE_BOTH"
+
+	IncomeTypeTransfer                 IncomeType = "TRANSFER"
+	IncomeTypeWelcomeBonus             IncomeType = "WELCOME_BONUS"
+	IncomeTypeRealizedPnl              IncomeType = "REALIZED_PNL"
+	IncomeTypeFundingFee               IncomeType = "FUNDING_FEE"
+	IncomeTypeCommission               IncomeType = "COMMISSION"
+	IncomeTypeInsuranceClear           IncomeType = "INSURANCE_CLEAR"
+	IncomeTypeReferralKickback         IncomeType = "REFERRAL_KICKBACK"
+	IncomeTypeCommissionRebate         IncomeType = "COMMISSION_REBATE"
+	IncomeTypeApiRebate                IncomeType = "API_REBATE"
+	IncomeTypeContestReward            IncomeType = "CONTEST_REWARD"
+	IncomeTypeCrossCollateralTransfer  IncomeType = "CROSS_COLLATERAL_TRANSFER"
+	IncomeTypeOptionsPremiumFee        IncomeType = "OPTIONS_PREMIUM_FEE"
+	IncomeTypeOptionsSettleProfit      IncomeType = "OPTIONS_SETTLE_PROFIT"
+	IncomeTypeInternalTransfer         IncomeType = "INTERNAL_TRANSFER"
+	IncomeTypeAutoExchange             IncomeType = "AUTO_EXCHANGE"
+	IncomeTypeDeliveredSettlement      IncomeType = "DELIVERED_SETTELMENT" // sic
+	IncomeTypeCoinSwapDeposit          IncomeType = "COIN_SWAP_DEPOSIT"
+	IncomeTypeCoinSwapWithdraw         IncomeType = "COIN_SWAP_WITHDRAW"
+	IncomeTypePositionLimitIncreaseFee IncomeType = "POSITION_LIMIT_INCREASE_FEE"
+
 	key_SYMBOL      = "symbol"
 	key_INTERVAL    = "interval"
 	key_LIMIT       = "limit"
@@ -122,6 +169,22 @@ const (
 	key_LEVERAGE    = "leverage"
 	key_MARGIN_TYPE = "marginType"
 
+	key_DUAL_SIDE_POSITION   = "dualSidePosition"
+	key_MULTI_ASSETS_MARGIN  = "multiAssetsMargin"
+	key_POSITION_SIDE        = "positionSide"
+	key_AMOUNT               = "amount"
+	key_POSITION_MARGIN_TYPE = "type"
+
+	key_NEW_CLIENT_ORDER_ID  = "newClientOrderId"
+	key_ORDER_ID             = "orderId"
+	key_ORIG_CLIENT_ORDER_ID = "origClientOrderId"
+	key_FROM_ID              = "fromId"
+	key_INCOME_TYPE          = "incomeType"
+
+	key_BATCH_ORDERS              = "batchOrders"
+	key_ORDER_ID_LIST             = "orderIdList"
+	key_ORIG_CLIENT_ORDER_ID_LIST = "origClientOrderIdList"
+
 	key_TIMESTAMP  = "timestamp"
 	key_SIGNATURE  = "signature"
 	key_RECVWINDOW = "recvWindow"
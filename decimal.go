@@ -0,0 +1,387 @@
+package binance
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an exact decimal number used for prices and quantities.
+// its value is coef * 10^-scale, the zero value is 0. the scale of parsed
+// values is preserved so "0.0100" is formatted back as "0.0100".
+type Decimal struct {
+	coef  *big.Int // nil means zero
+	scale int32    // digits after the decimal point, never negative
+}
+
+var bigTen = big.NewInt(10)
+
+// maxDecimalScale bounds the exponent of parsed values, binance never sends
+// more than a handful of decimals and larger exponents only allocate huge numbers
+const maxDecimalScale = 400
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+// NewDecimal returns value * 10^-scale, e.g. NewDecimal(12345, 2) is 123.45
+func NewDecimal(value int64, scale int32) Decimal {
+	if scale < 0 {
+		return Decimal{coef: new(big.Int).Mul(big.NewInt(value), pow10(-scale))}
+	}
+	return Decimal{coef: big.NewInt(value), scale: scale}
+}
+
+func NewDecimalFromInt(value int64) Decimal {
+	return NewDecimal(value, 0)
+}
+
+// NewDecimalFromFloat converts f using its shortest decimal representation
+func NewDecimalFromFloat(f float64) Decimal {
+	d, _ := ParseDecimal(strconv.FormatFloat(f, 'f', -1, 64))
+	return d
+}
+
+// ParseDecimal parses decimal strings like "-12.340", "0.00001" or "1e-8"
+func ParseDecimal(str string) (Decimal, error) {
+	s := strings.TrimSpace(str)
+	if s == "" {
+		return Decimal{}, fmt.Errorf("can not parse empty string as decimal")
+	}
+
+	exp := int64(0)
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.ParseInt(s[i+1:], 10, 32)
+		if err != nil {
+			return Decimal{}, fmt.Errorf("can not parse %q as decimal : invalid exponent", str)
+		}
+		exp = e
+		s = s[:i]
+	}
+
+	intPart, fracPart := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, fracPart = s[:i], s[i+1:]
+	}
+	digits := intPart + fracPart
+	if digits == "" || digits == "-" || digits == "+" {
+		return Decimal{}, fmt.Errorf("can not parse %q as decimal", str)
+	}
+	coef, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("can not parse %q as decimal", str)
+	}
+
+	scale := int64(len(fracPart)) - exp
+	if scale > maxDecimalScale || scale < -maxDecimalScale {
+		return Decimal{}, fmt.Errorf("can not parse %q as decimal : exponent out of range", str)
+	}
+	if scale < 0 {
+		coef.Mul(coef, pow10(int32(-scale)))
+		scale = 0
+	}
+	return Decimal{coef: coef, scale: int32(scale)}, nil
+}
+
+// MustParseDecimal is like ParseDecimal but panics on invalid input
+func MustParseDecimal(str string) Decimal {
+	d, err := ParseDecimal(str)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// parseDecimal is used for api responses, invalid or empty values are zero
+func parseDecimal(str string) Decimal {
+	if str == "" {
+		return Decimal{}
+	}
+	d, _ := ParseDecimal(str)
+	return d
+}
+
+// formatDecimal formats request parameters, zero values are left out of requests
+func formatDecimal(d Decimal) string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.String()
+}
+
+func (d Decimal) bigInt() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+	return d.coef
+}
+
+// rescale returns the coefficient of d at a larger scale
+func (d Decimal) rescale(scale int32) *big.Int {
+	if scale <= d.scale {
+		return d.bigInt()
+	}
+	return new(big.Int).Mul(d.bigInt(), pow10(scale-d.scale))
+}
+
+func maxScale(a, b Decimal) int32 {
+	if a.scale > b.scale {
+		return a.scale
+	}
+	return b.scale
+}
+
+func (d Decimal) Add(o Decimal) Decimal {
+	scale := maxScale(d, o)
+	return Decimal{coef: new(big.Int).Add(d.rescale(scale), o.rescale(scale)), scale: scale}
+}
+
+func (d Decimal) Sub(o Decimal) Decimal {
+	scale := maxScale(d, o)
+	return Decimal{coef: new(big.Int).Sub(d.rescale(scale), o.rescale(scale)), scale: scale}
+}
+
+func (d Decimal) Mul(o Decimal) Decimal {
+	return Decimal{coef: new(big.Int).Mul(d.bigInt(), o.bigInt()), scale: d.scale + o.scale}
+}
+
+// Div returns d / o rounded half away from zero to places digits, it panics if o is zero
+func (d Decimal) Div(o Decimal, places int32) Decimal {
+	if o.IsZero() {
+		panic("decimal division by zero")
+	}
+	num := new(big.Int).Mul(d.bigInt(), pow10(o.scale+places))
+	den := new(big.Int).Mul(o.bigInt(), pow10(d.scale))
+	return Decimal{coef: divRound(num, den), scale: places}
+}
+
+// divRound divides rounding half away from zero
+func divRound(num, den *big.Int) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+	r2 := new(big.Int).Abs(r)
+	r2.Lsh(r2, 1)
+	if r2.Cmp(new(big.Int).Abs(den)) >= 0 {
+		if num.Sign()*den.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
+
+func (d Decimal) Neg() Decimal {
+	return Decimal{coef: new(big.Int).Neg(d.bigInt()), scale: d.scale}
+}
+
+func (d Decimal) Abs() Decimal {
+	return Decimal{coef: new(big.Int).Abs(d.bigInt()), scale: d.scale}
+}
+
+// Sign returns -1, 0 or 1
+func (d Decimal) Sign() int {
+	return d.bigInt().Sign()
+}
+
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+func (d Decimal) IsPositive() bool {
+	return d.Sign() > 0
+}
+
+func (d Decimal) IsNegative() bool {
+	return d.Sign() < 0
+}
+
+// Cmp returns -1, 0 or 1 when d is less than, equal to or greater than o
+func (d Decimal) Cmp(o Decimal) int {
+	scale := maxScale(d, o)
+	return d.rescale(scale).Cmp(o.rescale(scale))
+}
+
+// Equal compares values, 1.10 equals 1.1
+func (d Decimal) Equal(o Decimal) bool {
+	return d.Cmp(o) == 0
+}
+
+func (d Decimal) LessThan(o Decimal) bool {
+	return d.Cmp(o) < 0
+}
+
+func (d Decimal) GreaterThan(o Decimal) bool {
+	return d.Cmp(o) > 0
+}
+
+func (d Decimal) Min(o Decimal) Decimal {
+	if o.LessThan(d) {
+		return o
+	}
+	return d
+}
+
+func (d Decimal) Max(o Decimal) Decimal {
+	if o.GreaterThan(d) {
+		return o
+	}
+	return d
+}
+
+// Round rounds half away from zero to places digits after the decimal point,
+// negative places round to tens, hundreds and so on, e.g. Round(-2) of 1250 is 1300
+func (d Decimal) Round(places int32) Decimal {
+	return d.toPlaces(places, divRound)
+}
+
+// Truncate drops the digits after places, rounding towards zero
+func (d Decimal) Truncate(places int32) Decimal {
+	return d.toPlaces(places, func(num, den *big.Int) *big.Int {
+		return new(big.Int).Quo(num, den)
+	})
+}
+
+// toPlaces keeps the scale non negative, negative places are applied as a step of 10^-places
+func (d Decimal) toPlaces(places int32, div func(num, den *big.Int) *big.Int) Decimal {
+	if places >= d.scale {
+		return d
+	}
+	if places < 0 {
+		unit := pow10(-places)
+		n := div(d.bigInt(), new(big.Int).Mul(pow10(d.scale), unit))
+		return Decimal{coef: n.Mul(n, unit)}
+	}
+	return Decimal{coef: div(d.bigInt(), pow10(d.scale-places)), scale: places}
+}
+
+// RoundToStep rounds half away from zero to the nearest multiple of step, e.g. a tick size
+func (d Decimal) RoundToStep(step Decimal) Decimal {
+	return d.toStep(step, divRound)
+}
+
+// TruncateToStep rounds towards zero to a multiple of step, e.g. a lot step size
+func (d Decimal) TruncateToStep(step Decimal) Decimal {
+	return d.toStep(step, func(num, den *big.Int) *big.Int {
+		return new(big.Int).Quo(num, den)
+	})
+}
+
+// IsMultipleOf reports whether d is an exact multiple of step
+func (d Decimal) IsMultipleOf(step Decimal) bool {
+	if step.IsZero() {
+		return true
+	}
+	return d.TruncateToStep(step).Equal(d)
+}
+
+func (d Decimal) toStep(step Decimal, div func(num, den *big.Int) *big.Int) Decimal {
+	if step.Sign() <= 0 {
+		return d
+	}
+	step = step.Normalize()
+	scale := maxScale(d, step)
+	n := div(d.rescale(scale), step.rescale(scale))
+	return Decimal{coef: n.Mul(n, step.bigInt()), scale: step.scale}
+}
+
+// Normalize removes trailing zeros after the decimal point, 1.2300 becomes 1.23
+func (d Decimal) Normalize() Decimal {
+	coef := new(big.Int).Set(d.bigInt())
+	scale := d.scale
+	r := new(big.Int)
+	for scale > 0 {
+		q, _ := new(big.Int).QuoRem(coef, bigTen, r)
+		if r.Sign() != 0 {
+			break
+		}
+		coef = q
+		scale--
+	}
+	return Decimal{coef: coef, scale: scale}
+}
+
+// Scale returns the number of digits after the decimal point
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// Float64 returns the nearest float64, for display and statistics only
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.bigInt()).String()
+	sign := ""
+	if d.Sign() < 0 {
+		sign = "-"
+	}
+	if d.scale == 0 {
+		return sign + digits
+	}
+	if len(digits) <= int(d.scale) {
+		digits = strings.Repeat("0", int(d.scale)-len(digits)+1) + digits
+	}
+	point := len(digits) - int(d.scale)
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// StringFixed rounds to places and formats with exactly places digits after the point
+func (d Decimal) StringFixed(places int32) string {
+	r := d.Round(places)
+	if r.scale < places {
+		r = Decimal{coef: r.rescale(places), scale: places}
+	}
+	return r.String()
+}
+
+// MarshalJSON encodes as a json string, the way binance sends numbers
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+// UnmarshalJSON accepts json strings, numbers and null
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*d = Decimal{}
+		return nil
+	}
+	str := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		var err error
+		str, err = strconv.Unquote(str)
+		if err != nil {
+			return err
+		}
+		if str == "" {
+			*d = Decimal{}
+			return nil
+		}
+	}
+	v, err := ParseDecimal(str)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Decimal) UnmarshalText(text []byte) error {
+	v, err := ParseDecimal(string(text))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
@@ -0,0 +1,77 @@
+package binance
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr string
+	}{
+		{"-12.340", "-12.340", ""},
+		{"0.00001", "0.00001", ""},
+		{"1e-8", "0.00000001", ""},
+		{"1.5E3", "1500", ""},
+		{" 42 ", "42", ""},
+		{"", "", "empty string"},
+		{".", "", "can not parse"},
+		{"1.2.3", "", "can not parse"},
+		{"1e", "", "invalid exponent"},
+		{"1e-2147483648", "", "exponent out of range"},
+		{"1e2147483647", "", "exponent out of range"},
+		{"1e99999999999", "", "invalid exponent"},
+		{"1e400", "", ""},
+		{"1e401", "", "exponent out of range"},
+		{"0.1e-400", "", "exponent out of range"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			d, err := ParseDecimal(tt.in)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.want != "" && d.String() != tt.want {
+				t.Errorf("ParseDecimal(%q) = %s, want %s", tt.in, d, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecimalRound(t *testing.T) {
+	tests := []struct {
+		in       string
+		places   int32
+		round    string
+		truncate string
+	}{
+		{"1.2345", 2, "1.23", "1.23"},
+		{"1.235", 2, "1.24", "1.23"},
+		{"-1.235", 2, "-1.24", "-1.23"},
+		{"1.5", 3, "1.5", "1.5"},
+		{"1250", -2, "1300", "1200"},
+		{"1249.99", -2, "1200", "1200"},
+		{"-1255", -1, "-1260", "-1250"},
+		{"49.9", -2, "0", "0"},
+		{"50", -2, "100", "0"},
+	}
+	for _, tt := range tests {
+		d := MustParseDecimal(tt.in)
+		r := d.Round(tt.places)
+		if r.String() != tt.round || r.scale < 0 {
+			t.Errorf("%s.Round(%d) = %s (scale %d), want %s", tt.in, tt.places, r, r.scale, tt.round)
+		}
+		tr := d.Truncate(tt.places)
+		if tr.String() != tt.truncate || tr.scale < 0 {
+			t.Errorf("%s.Truncate(%d) = %s (scale %d), want %s", tt.in, tt.places, tr, tr.scale, tt.truncate)
+		}
+	}
+}
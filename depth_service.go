@@ -0,0 +1,85 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+type DepthService struct {
+	c      *Client
+	symbol string
+	limit  int64 // 5, 10, 20, 50, 100, 500 or 1000, 500 when zero
+}
+
+func (c *Client) NewDepthService(symbol string, limit int64) *DepthService {
+	return &DepthService{
+		c:      c,
+		symbol: symbol,
+		limit:  limit,
+	}
+}
+
+// get an order book snapshot from binance rest api
+func (s *DepthService) GetDepth() (*DepthSnapshot, error) {
+	return s.GetDepthContext(context.Background())
+}
+
+func (s *DepthService) GetDepthContext(ctx context.Context) (*DepthSnapshot, error) {
+	if s.symbol == "" {
+		return nil, fmt.Errorf("error in fetching depth, symbol can not be empty")
+	}
+
+	req := request{
+		method:   http.MethodGet,
+		endpoint: endPointDepth,
+	}
+	req.setParam(key_SYMBOL, s.symbol)
+	if s.limit > 0 {
+		req.setParam(key_LIMIT, s.limit)
+	}
+	req.weight = depthWeight(s.limit)
+
+	data, err := s.c.callAPI(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	var res jsonDepthSnapshot
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		s.c.logger.Error("error in parsing depth rest api", "err", err, "data", string(data))
+		return nil, err
+	}
+	return &DepthSnapshot{
+		Symbol:          s.symbol,
+		LastUpdateId:    res.LastUpdateId,
+		EventTime:       res.EventTime,
+		TransactionTime: res.TransactionTime,
+		Bids:            parseBookEntries(res.Bids),
+		Asks:            parseBookEntries(res.Asks),
+	}, nil
+}
+
+type jsonDepthSnapshot struct {
+	LastUpdateId    int64      `json:"lastUpdateId"`
+	EventTime       int64      `json:"E"`
+	TransactionTime int64      `json:"T"`
+	Bids            [][]string `json:"bids"`
+	Asks            [][]string `json:"asks"`
+}
+
+func parseBookEntries(levels [][]string) []OrderBookEntry {
+	entries := make([]OrderBookEntry, 0, len(levels))
+	for _, level := range levels {
+		if len(level) < 2 {
+			continue
+		}
+		entries = append(entries, OrderBookEntry{
+			Price:    parseDecimal(level[0]),
+			Quantity: parseDecimal(level[1]),
+		})
+	}
+	return entries
+}
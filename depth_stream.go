@@ -1,9 +1,9 @@
 package binance
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"strconv"
 	"strings"
 )
@@ -18,48 +18,61 @@ type DepthStream struct {
 
 func (c *Client) NewDepthStream(symbol string, level int) *DepthStream {
 	endpoint := fmt.Sprintf("%s@depth%s", strings.ToLower(symbol), strconv.Itoa(level))
-	url := fmt.Sprintf("%s/%s", baseWsMainURL, endpoint)
+	url := fmt.Sprintf("%s/%s", c.wsBaseURL, endpoint)
 
 	return &DepthStream{
 		c:      c,
 		symbol: symbol,
 		level:  level,
 		out:    make(chan *OrderBookEvent),
-		wss: &WebSocketStream{
-			url:     url,
-			timeout: 2 * 60 * 60 * 1000,
-		},
+		wss:    newWebSocketStream(url, c.logger),
 	}
 }
 
 func (s *DepthStream) Start() <-chan *OrderBookEvent {
-	s.wss.isActive = true
-	go s.startStream()
+	return s.StartContext(context.Background())
+}
+
+// StartContext starts the stream, the out channel is closed once ctx is cancelled
+func (s *DepthStream) StartContext(ctx context.Context) <-chan *OrderBookEvent {
+	ctx = s.wss.start(ctx)
+	go s.startStream(ctx)
 	return s.out
 }
 
 func (s *DepthStream) Stop() {
-	s.wss.isActive = false
+	s.wss.stop()
+}
+
+// Events reports reconnects of the stream, it is closed with the out channel
+func (s *DepthStream) Events() <-chan StreamEvent {
+	return s.wss.events
 }
 
-func (s *DepthStream) startStream() {
+func (s *DepthStream) startStream(ctx context.Context) {
 	defer close(s.out)
+	defer s.wss.finish()
 	messageCount := 0
 	for {
-		msg, err := s.wss.getNextMessage()
+		msg, err := s.wss.getNextMessage(ctx)
 		if err != nil {
 			break
 		}
 		event := s.parseResponse(msg)
 		if event == nil {
-			log.Println("error order book event is nil")
+			s.c.logger.Warn("error order book event is nil", "symbol", s.symbol)
 			continue
 		}
 
-		s.out <- event
-		messageCount += 1
+		select {
+		case s.out <- event:
+			messageCount += 1
+		case <-ctx.Done():
+			s.c.logger.Info("depth stream closed", "symbol", s.symbol, "events", messageCount)
+			return
+		}
 	}
-	log.Printf("sent %d depth events for %s", messageCount, s.symbol)
+	s.c.logger.Info("depth stream closed", "symbol", s.symbol, "events", messageCount)
 }
 
 type jsonDepthEvent struct {
@@ -67,23 +80,35 @@ type jsonDepthEvent struct {
 	EventTime      int64           `json:"E"`
 	TransationTime int64           `json:"T"`
 	Symbol         string          `json:"s"`
+	FirstUpdateId  int64           `json:"U"`
+	FinalUpdateId  int64           `json:"u"`
+	PrevUpdateId   int64           `json:"pu"`
 	Bids           [][]interface{} `json:"b"`
 	Asks           [][]interface{} `json:"a"`
 }
 
 func (s *DepthStream) parseResponse(data []byte) *OrderBookEvent {
+	event, err := parseDepthEvent(data)
+	if err != nil {
+		s.c.logger.Error("error in parsing depth event", "err", err, "data", string(data))
+		return nil
+	}
+	return event
+}
+
+// parseDepthEvent parses a <symbol>@depth<level> message
+func parseDepthEvent(data []byte) (*OrderBookEvent, error) {
 	var event jsonDepthEvent
 	err := json.Unmarshal(data, &event)
 	if err != nil {
-		log.Println("error in parsing depth event : ", err, string(data))
-		return nil
+		return nil, err
 	}
 
 	bids := make([]OrderBookEntry, 0)
 	for _, b := range event.Bids {
 		BidEntry := OrderBookEntry{
-			Price:    parseFloat(b[0].(string)),
-			Quantity: parseFloat(b[1].(string)),
+			Price:    parseDecimal(b[0].(string)),
+			Quantity: parseDecimal(b[1].(string)),
 		}
 		bids = append(bids, BidEntry)
 	}
@@ -91,18 +116,21 @@ func (s *DepthStream) parseResponse(data []byte) *OrderBookEvent {
 	asks := make([]OrderBookEntry, 0)
 	for _, a := range event.Asks {
 		AskEntry := OrderBookEntry{
-			Price:    parseFloat(a[0].(string)),
-			Quantity: parseFloat(a[1].(string)),
+			Price:    parseDecimal(a[0].(string)),
+			Quantity: parseDecimal(a[1].(string)),
 		}
 		asks = append(asks, AskEntry)
 	}
 
 	return &OrderBookEvent{
-		EventType:       event.EventType,
-		EventTime:       event.EventTime,
-		TransactionTime: event.TransationTime,
-		Symbol:          event.Symbol,
-		Bids:            bids,
-		Asks:            asks,
-	}
+		EventType:         event.EventType,
+		EventTime:         event.EventTime,
+		TransactionTime:   event.TransationTime,
+		Symbol:            event.Symbol,
+		FirstUpdateId:     event.FirstUpdateId,
+		FinalUpdateId:     event.FinalUpdateId,
+		PrevFinalUpdateId: event.PrevUpdateId,
+		Bids:              bids,
+		Asks:              asks,
+	}, nil
 }
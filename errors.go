@@ -0,0 +1,76 @@
+package binance
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// APIError is returned for every non-200 response from the rest api, for
+// each rejected order of a batch and for rejected websocket requests, the
+// last two with a zero StatusCode
+type APIError struct {
+	StatusCode   int
+	Code         int64
+	Message      string
+	Endpoint     string
+	UsedWeight   int64 // X-Mbx-Used-Weight-1m
+	OrderCount10 int64 // X-Mbx-Order-Count-10s
+	OrderCount1m int64 // X-Mbx-Order-Count-1m
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("binance api error (status %d, code %d) on %s : %s", e.StatusCode, e.Code, e.Endpoint, e.Message)
+}
+
+// Is matches sentinel errors by binance error code, or by http status
+// when the sentinel has no code set (rate limit and ip ban)
+func (e *APIError) Is(target error) bool {
+	t, ok := target.(*APIError)
+	if !ok {
+		return false
+	}
+	if t.Code != 0 {
+		return t.Code == e.Code
+	}
+	return t.StatusCode != 0 && t.StatusCode == e.StatusCode
+}
+
+// sentinels for common error codes, use with errors.Is
+var (
+	ErrInsufficientMargin         = &APIError{Code: -2019, Message: "margin is insufficient"}
+	ErrUnknownOrder               = &APIError{Code: -2011, Message: "unknown order sent"}
+	ErrOrderDoesNotExist          = &APIError{Code: -2013, Message: "order does not exist"}
+	ErrTimestampOutsideRecvWindow = &APIError{Code: -1021, Message: "timestamp for this request is outside of the recvWindow"}
+	ErrInvalidSymbol              = &APIError{Code: -1121, Message: "invalid symbol"}
+	ErrDuplicateClientOrderId     = &APIError{Code: -4116, Message: "clientOrderId is duplicated"}
+	ErrNoNeedToChangePositionSide = &APIError{Code: -4059, Message: "no need to change position side"}
+	ErrNoNeedToChangeMarginType   = &APIError{Code: -4046, Message: "no need to change margin type"}
+	ErrNoNeedToChangeMultiAssets  = &APIError{Code: -4171, Message: "multi-assets mode is already set"}
+	ErrPositionSideMismatch       = &APIError{Code: -4061, Message: "order's position side does not match user's setting"}
+	ErrRateLimited                = &APIError{StatusCode: http.StatusTooManyRequests, Message: "request rate limit exceeded"}
+	ErrIPBanned                   = &APIError{StatusCode: http.StatusTeapot, Message: "ip has been auto-banned"}
+)
+
+type jsonAPIError struct {
+	Code    int64  `json:"code"`
+	Message string `json:"msg"`
+}
+
+func newAPIError(res *http.Response, endpoint string, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode:   res.StatusCode,
+		Endpoint:     endpoint,
+		UsedWeight:   ParseInt(res.Header.Get("X-Mbx-Used-Weight-1m")),
+		OrderCount10: ParseInt(res.Header.Get("X-Mbx-Order-Count-10s")),
+		OrderCount1m: ParseInt(res.Header.Get("X-Mbx-Order-Count-1m")),
+	}
+	var e jsonAPIError
+	if err := json.Unmarshal(body, &e); err != nil || e.Code == 0 {
+		apiErr.Message = string(body)
+		return apiErr
+	}
+	apiErr.Code = e.Code
+	apiErr.Message = e.Message
+	return apiErr
+}
@@ -1,30 +1,35 @@
 package binance
 
 import (
+	"context"
 	"encoding/json"
-	"log"
 	"net/http"
 )
 
-func (c *Client) GetExchangeInfo() *ExchangeInfo {
+func (c *Client) GetExchangeInfo() (*ExchangeInfo, error) {
+	return c.GetExchangeInfoContext(context.Background())
+}
+
+func (c *Client) GetExchangeInfoContext(ctx context.Context) (*ExchangeInfo, error) {
 
 	req := request{
 		method:   http.MethodGet,
 		endpoint: endPointExchangeInfo,
 		query:    nil,
 	}
-	data, err := c.callAPI(&req)
+	data, err := c.callAPI(ctx, &req)
 	if err != nil {
-		return &ExchangeInfo{}
+		return nil, err
 	}
 	var exchangeInfo ExchangeInfo
 	err = json.Unmarshal(data, &exchangeInfo)
 	if err != nil {
-		log.Println("error in parsing exchange info", err, string(data))
-		return &ExchangeInfo{}
+		c.logger.Error("error in parsing exchange info", "err", err)
+		return nil, err
 	}
 
-	return &exchangeInfo
+	c.limiter.setLimits(exchangeInfo.RateLimits)
+	return &exchangeInfo, nil
 }
 
 type ExchangeInfo struct {
@@ -63,4 +68,13 @@ type InfoSymbol struct {
 	QuantityPrecision        int64  `json:"quantityPrecision"`
 	BaseAssetPrecision       int64  `json:"baseAssetPrecision"`
 	QuotePrecision           int64  `json:"quotePrecision"`
+
+	// filters, nil when the symbol doesn't have them
+	PriceFilter      *PriceFilter        `json:"-"`
+	LotSize          *LotSizeFilter      `json:"-"`
+	MarketLotSize    *LotSizeFilter      `json:"-"`
+	PercentPrice     *PercentPriceFilter `json:"-"`
+	MinNotional      *MinNotionalFilter  `json:"-"`
+	MaxNumOrders     *MaxNumOrdersFilter `json:"-"`
+	MaxNumAlgoOrders *MaxNumOrdersFilter `json:"-"`
 }
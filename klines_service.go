@@ -1,8 +1,9 @@
 package binance
 
 import (
+	"context"
 	"encoding/json"
-	"log"
+	"fmt"
 	"net/http"
 )
 
@@ -27,12 +28,15 @@ func (c *Client) NewKlinesService(symbol, interval string, limit, startTime, end
 }
 
 // get klines from binance rest api
-func (s *KlineService) GetKlines() []*Kline {
+func (s *KlineService) GetKlines() ([]*Kline, error) {
+	return s.GetKlinesContext(context.Background())
+}
+
+func (s *KlineService) GetKlinesContext(ctx context.Context) ([]*Kline, error) {
 
 	klines := make([]*Kline, 0)
 	if s.symbol == "" || s.interval == "" {
-		log.Println("error in fetching klines, symbol and interval can not be empty")
-		return klines
+		return nil, fmt.Errorf("error in fetching klines, symbol and interval can not be empty")
 	}
 
 	req := request{
@@ -44,22 +48,23 @@ func (s *KlineService) GetKlines() []*Kline {
 	if s.limit > 0 {
 		req.setParam(key_LIMIT, s.limit)
 	}
+	req.weight = klinesWeight(s.limit)
 	if s.startTime > 0 && s.endTime > 0 {
 		req.setParam(key_STARTTIME, s.startTime)
 		req.setParam(key_ENDTIME, s.endTime)
 	}
 
-	data, err := s.c.callAPI(&req)
+	data, err := s.c.callAPI(ctx, &req)
 	if err != nil {
-		return klines
+		return nil, err
 	}
 	// log.Println(string(data))
 
 	var klist [][]interface{}
 	err = json.Unmarshal(data, &klist)
 	if err != nil {
-		log.Println("error in parsing klines rest api : ", err, string(data))
-		return klines
+		s.c.logger.Error("error in parsing klines rest api", "err", err, "data", string(data))
+		return nil, err
 	}
 	// log.Println(klist)
 
@@ -69,20 +74,20 @@ func (s *KlineService) GetKlines() []*Kline {
 			Interval:            s.interval,
 			EventTime:           int64(k[6].(float64)),
 			OpenTime:            int64(k[0].(float64)),
-			OpenPrice:           parseFloat(k[1].(string)),
-			HighPrice:           parseFloat(k[2].(string)),
-			LowPrice:            parseFloat(k[3].(string)),
-			ClosePrice:          parseFloat(k[4].(string)),
-			BaseVolume:          parseFloat(k[5].(string)),
+			OpenPrice:           parseDecimal(k[1].(string)),
+			HighPrice:           parseDecimal(k[2].(string)),
+			LowPrice:            parseDecimal(k[3].(string)),
+			ClosePrice:          parseDecimal(k[4].(string)),
+			BaseVolume:          parseDecimal(k[5].(string)),
 			CloseTime:           int64(k[6].(float64)),
-			QuoteVolume:         parseFloat(k[7].(string)),
+			QuoteVolume:         parseDecimal(k[7].(string)),
 			TradeCount:          int64(k[8].(float64)),
-			TakerBuyBaseVolume:  parseFloat(k[9].(string)),
-			TakerBuyQuoteVolume: parseFloat(k[10].(string)),
+			TakerBuyBaseVolume:  parseDecimal(k[9].(string)),
+			TakerBuyQuoteVolume: parseDecimal(k[10].(string)),
 		}
 		// log.Println(kline)
 		klines = append(klines, kline)
 	}
 
-	return klines
+	return klines, nil
 }
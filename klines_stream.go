@@ -1,9 +1,9 @@
 package binance
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"math/rand"
 	"strings"
 )
@@ -19,28 +19,30 @@ type KlineStream struct {
 
 func (c *Client) NewKlineStream(symbol string, interval string, dropProb float32) *KlineStream {
 	if symbol == "" || interval == "" || dropProb < 0 || dropProb > 1 {
-		log.Println("error in kline stream, empty symbol or interval")
+		c.logger.Error("error in kline stream, empty symbol or interval", "symbol", symbol, "interval", interval)
 	}
 	// endpoint := fmt.Sprintf("%s_perpetual@continuousKline_%s", strings.ToLower(symbol), interval)
 	endpoint := fmt.Sprintf("%s@kline_%s", strings.ToLower(symbol), interval)
-	url := fmt.Sprintf("%s/%s", baseWsMainURL, endpoint)
+	url := fmt.Sprintf("%s/%s", c.wsBaseURL, endpoint)
 
 	return &KlineStream{
 		c:        c,
 		symbol:   symbol,
 		interval: interval,
 		out:      make(chan *Kline),
-		wss: &WebSocketStream{
-			url:     url,
-			timeout: 2 * 60 * 60 * 1000,
-		},
+		wss:      newWebSocketStream(url, c.logger),
 		dropProb: dropProb,
 	}
 }
 
 func (s *KlineStream) Start() <-chan *Kline {
-	s.wss.isActive = true
-	go s.startStream()
+	return s.StartContext(context.Background())
+}
+
+// StartContext starts the stream, the out channel is closed once ctx is cancelled
+func (s *KlineStream) StartContext(ctx context.Context) <-chan *Kline {
+	ctx = s.wss.start(ctx)
+	go s.startStream(ctx)
 	return s.out
 }
 
@@ -49,15 +51,21 @@ func (s *KlineStream) Start() <-chan *Kline {
 // }
 
 func (s *KlineStream) Stop() {
-	s.wss.isActive = false
+	s.wss.stop()
+}
+
+// Events reports reconnects of the stream, it is closed with the out channel
+func (s *KlineStream) Events() <-chan StreamEvent {
+	return s.wss.events
 }
 
-func (s *KlineStream) startStream() {
+func (s *KlineStream) startStream(ctx context.Context) {
 	defer close(s.out)
+	defer s.wss.finish()
 	messageCount := 0
 
 	for {
-		msg, err := s.wss.getNextMessage()
+		msg, err := s.wss.getNextMessage(ctx)
 		if err != nil {
 			break
 		}
@@ -68,11 +76,15 @@ func (s *KlineStream) startStream() {
 		}
 
 		if kline == nil {
-			log.Println("error, kline event is nil", s.symbol, kline)
+			s.c.logger.Warn("error, kline event is nil", "symbol", s.symbol)
 			continue
 		}
-		messageCount += 1
-		s.out <- kline
+		select {
+		case s.out <- kline:
+			messageCount += 1
+		case <-ctx.Done():
+			return
+		}
 	}
 }
 
@@ -103,11 +115,20 @@ type jsonWsKlineEvent struct {
 }
 
 func (s *KlineStream) parseResponse(data []byte) *Kline {
+	kline, err := parseKlineEvent(data)
+	if err != nil {
+		s.c.logger.Error("error in parsing kline", "err", err, "data", string(data))
+		return nil
+	}
+	return kline
+}
+
+// parseKlineEvent parses a <symbol>@kline_<interval> message
+func parseKlineEvent(data []byte) (*Kline, error) {
 	var event jsonWsKlineEvent
 	err := json.Unmarshal(data, &event)
 	if err != nil {
-		log.Println("error in parsing kline : ", err, string(data))
-		return nil
+		return nil, err
 	}
 	k := event.Kline
 
@@ -117,15 +138,15 @@ func (s *KlineStream) parseResponse(data []byte) *Kline {
 		EventTime:           event.Time,
 		OpenTime:            k.StartTime,
 		CloseTime:           k.EndTime,
-		OpenPrice:           parseFloat(k.Open),
-		HighPrice:           parseFloat(k.High),
-		LowPrice:            parseFloat(k.Low),
-		ClosePrice:          parseFloat(k.Close),
-		BaseVolume:          parseFloat(k.BaseVolume),
-		QuoteVolume:         parseFloat(k.QuoteAssetVolume),
+		OpenPrice:           parseDecimal(k.Open),
+		HighPrice:           parseDecimal(k.High),
+		LowPrice:            parseDecimal(k.Low),
+		ClosePrice:          parseDecimal(k.Close),
+		BaseVolume:          parseDecimal(k.BaseVolume),
+		QuoteVolume:         parseDecimal(k.QuoteAssetVolume),
 		TradeCount:          event.Kline.TradeCount,
-		TakerBuyBaseVolume:  parseFloat(k.TakerBuyBaseVolume),
-		TakerBuyQuoteVolume: parseFloat(k.TakerBuyQuoteVolume),
+		TakerBuyBaseVolume:  parseDecimal(k.TakerBuyBaseVolume),
+		TakerBuyQuoteVolume: parseDecimal(k.TakerBuyQuoteVolume),
 		IsFinal:             k.IsFinal,
-	}
+	}, nil
 }
@@ -0,0 +1,180 @@
+package binance
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Logger is a levelled, structured logger. args are alternating key value
+// pairs, the same convention as log/slog, so a *slog.Logger can be used directly.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+type LogLevel int
+
+const (
+	LogLevelDebug LogLevel = iota
+	LogLevelInfo
+	LogLevelWarn
+	LogLevelError
+)
+
+func (l LogLevel) String() string {
+	switch l {
+	case LogLevelDebug:
+		return "DEBUG"
+	case LogLevelInfo:
+		return "INFO"
+	case LogLevelWarn:
+		return "WARN"
+	}
+	return "ERROR"
+}
+
+// nopLogger is the default logger, the client is silent unless a logger is set
+type nopLogger struct{}
+
+func (nopLogger) Debug(msg string, args ...any) {}
+func (nopLogger) Info(msg string, args ...any)  {}
+func (nopLogger) Warn(msg string, args ...any)  {}
+func (nopLogger) Error(msg string, args ...any) {}
+
+// stdLogger writes key=value lines to a standard library logger
+type stdLogger struct {
+	l     *log.Logger
+	level LogLevel
+}
+
+// NewStdLogger returns a Logger writing messages at or above level to l
+func NewStdLogger(l *log.Logger, level LogLevel) Logger {
+	return &stdLogger{l: l, level: level}
+}
+
+func (s *stdLogger) Debug(msg string, args ...any) { s.log(LogLevelDebug, msg, args) }
+func (s *stdLogger) Info(msg string, args ...any)  { s.log(LogLevelInfo, msg, args) }
+func (s *stdLogger) Warn(msg string, args ...any)  { s.log(LogLevelWarn, msg, args) }
+func (s *stdLogger) Error(msg string, args ...any) { s.log(LogLevelError, msg, args) }
+
+func (s *stdLogger) log(level LogLevel, msg string, args []any) {
+	if level < s.level {
+		return
+	}
+	var sb strings.Builder
+	sb.WriteString(level.String())
+	sb.WriteString(" ")
+	sb.WriteString(msg)
+	for i := 0; i < len(args); i += 2 {
+		if i+1 < len(args) {
+			fmt.Fprintf(&sb, " %v=%v", args[i], args[i+1])
+		} else {
+			fmt.Fprintf(&sb, " !BADKEY=%v", args[i])
+		}
+	}
+	s.l.Println(sb.String())
+}
+
+const redacted = "[REDACTED]"
+
+var (
+	sensitiveKeys = map[string]bool{
+		"apikey":    true,
+		"secretkey": true,
+		"signature": true,
+		"listenkey": true,
+	}
+	signatureParam = regexp.MustCompile(`(signature=)[^&\s"]+`)
+)
+
+// redactingLogger wraps every logger set on the client. it masks values of
+// sensitive keys, signature query parameters and any registered secret
+// (api key, listen keys) wherever they appear in the message or arguments.
+type redactingLogger struct {
+	next    Logger
+	mu      sync.RWMutex
+	secrets []string
+}
+
+func newRedactingLogger(next Logger) *redactingLogger {
+	return &redactingLogger{next: next}
+}
+
+func (r *redactingLogger) addSecret(secret string) {
+	if secret == "" {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, s := range r.secrets {
+		if s == secret {
+			return
+		}
+	}
+	r.secrets = append(r.secrets, secret)
+}
+
+func (r *redactingLogger) removeSecret(secret string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, s := range r.secrets {
+		if s == secret {
+			r.secrets = append(r.secrets[:i], r.secrets[i+1:]...)
+			return
+		}
+	}
+}
+
+func (r *redactingLogger) Debug(msg string, args ...any) {
+	r.next.Debug(r.redact(msg), r.redactArgs(args)...)
+}
+
+func (r *redactingLogger) Info(msg string, args ...any) {
+	r.next.Info(r.redact(msg), r.redactArgs(args)...)
+}
+
+func (r *redactingLogger) Warn(msg string, args ...any) {
+	r.next.Warn(r.redact(msg), r.redactArgs(args)...)
+}
+
+func (r *redactingLogger) Error(msg string, args ...any) {
+	r.next.Error(r.redact(msg), r.redactArgs(args)...)
+}
+
+func (r *redactingLogger) redact(str string) string {
+	str = signatureParam.ReplaceAllString(str, "${1}"+redacted)
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, secret := range r.secrets {
+		str = strings.ReplaceAll(str, secret, redacted)
+	}
+	return str
+}
+
+func (r *redactingLogger) redactArgs(args []any) []any {
+	out := make([]any, len(args))
+	for i, arg := range args {
+		if i%2 == 1 {
+			if key, ok := args[i-1].(string); ok && sensitiveKeys[strings.ToLower(key)] {
+				out[i] = redacted
+				continue
+			}
+		}
+		switch v := arg.(type) {
+		case string:
+			out[i] = r.redact(v)
+		case error:
+			out[i] = r.redact(v.Error())
+		case fmt.Stringer:
+			out[i] = r.redact(v.String())
+		default:
+			out[i] = arg
+		}
+	}
+	return out
+}
@@ -0,0 +1,382 @@
+package binance
+
+/* local order book kept in sync with the diff depth stream */
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	orderBookSnapshotLimit = 1000
+	vwapPlaces             = 8
+)
+
+var (
+	ErrOrderBookNotSynced = errors.New("order book is not synced")
+	ErrInsufficientDepth  = errors.New("not enough depth in the order book")
+)
+
+// StreamEventResynced is reported by an OrderBook which lost an update and
+// is reloaded from a new snapshot
+const StreamEventResynced StreamEventType = "RESYNCED"
+
+// OrderBook keeps the full order book of a symbol from a rest snapshot and
+// the @depth@100ms diff stream. a dropped connection or a gap in the update
+// ids (U, u, pu) marks the book unsynced until it is reloaded from a new
+// snapshot. every method is safe for concurrent use
+type OrderBook struct {
+	c      *Client
+	symbol string
+	limit  int64 // levels of the snapshot
+	wss    *WebSocketStream
+
+	mu              sync.RWMutex
+	synced          bool
+	lastUpdateId    int64
+	eventTime       int64
+	transactionTime int64
+	bids            []OrderBookEntry // highest price first
+	asks            []OrderBookEntry // lowest price first
+	ready           chan struct{}    // closed on the first sync
+
+	loaded bool // a snapshot is loaded and waits for its first event
+
+	// only used by the stream goroutine
+	snapshotTries int
+	snapshotAfter time.Time
+}
+
+// NewOrderBook returns the order book of a symbol, loaded from a snapshot of
+// 1000 levels. levels beyond the snapshot are added as they change
+func (c *Client) NewOrderBook(symbol string) *OrderBook {
+	url := fmt.Sprintf("%s/%s@depth@100ms", c.wsBaseURL, strings.ToLower(symbol))
+	b := &OrderBook{
+		c:      c,
+		symbol: symbol,
+		limit:  orderBookSnapshotLimit,
+		wss:    newWebSocketStream(url, c.logger),
+		ready:  make(chan struct{}),
+	}
+	b.wss.onDisconnect = b.unsync
+	return b
+}
+
+func (b *OrderBook) Start() {
+	b.StartContext(context.Background())
+}
+
+// StartContext starts syncing the book until ctx is cancelled or the book is stopped
+func (b *OrderBook) StartContext(ctx context.Context) {
+	ctx = b.wss.start(ctx)
+	go b.startStream(ctx)
+}
+
+func (b *OrderBook) Stop() {
+	b.wss.stop()
+}
+
+// Events reports reconnects and resyncs of the book
+func (b *OrderBook) Events() <-chan StreamEvent {
+	return b.wss.events
+}
+
+// WaitSynced blocks until the book is synced for the first time
+func (b *OrderBook) WaitSynced(ctx context.Context) error {
+	select {
+	case <-b.ready:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (b *OrderBook) Synced() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.synced
+}
+
+// unsync drops the book state when the connection drops, the updates sent
+// until the reconnect are lost and the first event after it loads a new snapshot
+func (b *OrderBook) unsync() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.synced = false
+	b.loaded = false
+}
+
+func (b *OrderBook) startStream(ctx context.Context) {
+	defer b.wss.finish()
+	messageCount := 0
+	for {
+		msg, err := b.wss.getNextMessage(ctx)
+		if err != nil {
+			break
+		}
+		event, err := parseDepthEvent(msg)
+		if err != nil {
+			b.c.logger.Error("error in parsing depth event", "err", err, "data", string(msg))
+			continue
+		}
+		b.handle(ctx, event)
+		messageCount += 1
+	}
+	b.mu.Lock()
+	b.synced = false
+	b.mu.Unlock()
+	b.c.logger.Info("order book closed", "symbol", b.symbol, "events", messageCount)
+}
+
+// handle applies an event following binance's rules for a local order book:
+// events ending before the snapshot are dropped, the first applied event
+// contains the snapshot id and every later event continues the previous one
+func (b *OrderBook) handle(ctx context.Context, event *OrderBookEvent) {
+	b.mu.Lock()
+	if b.synced && event.PrevFinalUpdateId != b.lastUpdateId {
+		b.c.logger.Warn("gap in order book updates, resyncing", "symbol", b.symbol,
+			"lastUpdateId", b.lastUpdateId, "pu", event.PrevFinalUpdateId)
+		b.synced = false
+		b.loaded = false
+		b.wss.emit(StreamEvent{Type: StreamEventResynced})
+	}
+	if b.synced {
+		b.apply(event)
+		b.mu.Unlock()
+		return
+	}
+	// no snapshot yet or the snapshot is older than the stream
+	needSnapshot := !b.loaded || event.FirstUpdateId > b.lastUpdateId
+	b.mu.Unlock()
+
+	if needSnapshot {
+		snapshot := b.fetchSnapshot(ctx)
+		if snapshot == nil {
+			return
+		}
+		b.mu.Lock()
+		b.loaded = true
+		b.lastUpdateId = snapshot.LastUpdateId
+		b.eventTime = snapshot.EventTime
+		b.transactionTime = snapshot.TransactionTime
+		b.bids = snapshot.Bids
+		b.asks = snapshot.Asks
+		b.mu.Unlock()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if event.FinalUpdateId < b.lastUpdateId {
+		return
+	}
+	if event.FirstUpdateId > b.lastUpdateId {
+		// the stream is still ahead of the snapshot, reloaded on the next event
+		b.loaded = false
+		return
+	}
+	b.apply(event)
+	b.synced = true
+	b.loaded = false
+	select {
+	case <-b.ready:
+	default:
+		close(b.ready)
+	}
+}
+
+// fetchSnapshot loads a rest snapshot, backing off after failures
+func (b *OrderBook) fetchSnapshot(ctx context.Context) *DepthSnapshot {
+	if time.Now().Before(b.snapshotAfter) {
+		return nil
+	}
+	snapshot, err := b.c.NewDepthService(b.symbol, b.limit).GetDepthContext(ctx)
+	if err != nil {
+		b.snapshotTries += 1
+		b.snapshotAfter = time.Now().Add(wsBackoff(b.snapshotTries))
+		b.c.logger.Error("error in loading order book snapshot", "err", err, "symbol", b.symbol, "attempt", b.snapshotTries)
+		return nil
+	}
+	b.snapshotTries = 0
+	return snapshot
+}
+
+// apply sets the changed levels, binance sends absolute quantities and zero to remove a level
+func (b *OrderBook) apply(event *OrderBookEvent) {
+	for _, entry := range event.Bids {
+		b.bids = setBookLevel(b.bids, entry, true)
+	}
+	for _, entry := range event.Asks {
+		b.asks = setBookLevel(b.asks, entry, false)
+	}
+	b.lastUpdateId = event.FinalUpdateId
+	b.eventTime = event.EventTime
+	b.transactionTime = event.TransactionTime
+}
+
+func setBookLevel(levels []OrderBookEntry, entry OrderBookEntry, desc bool) []OrderBookEntry {
+	i := sort.Search(len(levels), func(i int) bool {
+		if desc {
+			return levels[i].Price.Cmp(entry.Price) <= 0
+		}
+		return levels[i].Price.Cmp(entry.Price) >= 0
+	})
+	found := i < len(levels) && levels[i].Price.Equal(entry.Price)
+	switch {
+	case entry.Quantity.IsZero() && found:
+		return append(levels[:i], levels[i+1:]...)
+	case entry.Quantity.IsZero():
+		return levels
+	case found:
+		levels[i].Quantity = entry.Quantity
+		return levels
+	}
+	levels = append(levels, OrderBookEntry{})
+	copy(levels[i+1:], levels[i:])
+	levels[i] = entry
+	return levels
+}
+
+// BestBid returns the highest bid, false until synced or when there are no bids
+func (b *OrderBook) BestBid() (OrderBookEntry, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if !b.synced || len(b.bids) == 0 {
+		return OrderBookEntry{}, false
+	}
+	return b.bids[0], true
+}
+
+// BestAsk returns the lowest ask, false until synced or when there are no asks
+func (b *OrderBook) BestAsk() (OrderBookEntry, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if !b.synced || len(b.asks) == 0 {
+		return OrderBookEntry{}, false
+	}
+	return b.asks[0], true
+}
+
+func (b *OrderBook) Mid() (Decimal, bool) {
+	bid, ask, ok := b.top()
+	if !ok {
+		return Decimal{}, false
+	}
+	// one more digit keeps the half exact
+	return bid.Add(ask).Div(NewDecimalFromInt(2), maxScale(bid, ask)+1), true
+}
+
+func (b *OrderBook) Spread() (Decimal, bool) {
+	bid, ask, ok := b.top()
+	if !ok {
+		return Decimal{}, false
+	}
+	return ask.Sub(bid), true
+}
+
+func (b *OrderBook) top() (Decimal, Decimal, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if !b.synced || len(b.bids) == 0 || len(b.asks) == 0 {
+		return Decimal{}, Decimal{}, false
+	}
+	return b.bids[0].Price, b.asks[0].Price, true
+}
+
+// QuantityAt returns the quantity of the level at price on either side, zero without a level
+func (b *OrderBook) QuantityAt(price Decimal) Decimal {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if !b.synced {
+		return Decimal{}
+	}
+	for _, levels := range [][]OrderBookEntry{b.bids, b.asks} {
+		for _, level := range levels {
+			if level.Price.Equal(price) {
+				return level.Quantity
+			}
+		}
+	}
+	return Decimal{}
+}
+
+// DepthAt returns the quantity a market order of side can fill up to price:
+// asks at or below price for a buy, bids at or above price for a sell
+func (b *OrderBook) DepthAt(side SideType, price Decimal) Decimal {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	var depth Decimal
+	if !b.synced {
+		return depth
+	}
+	levels, desc := b.takerLevels(side)
+	for _, level := range levels {
+		if desc && level.Price.LessThan(price) || !desc && level.Price.GreaterThan(price) {
+			break
+		}
+		depth = depth.Add(level.Quantity)
+	}
+	return depth
+}
+
+// VWAP returns the average price of filling size with a market order of
+// side, a buy fills against the asks and a sell against the bids
+func (b *OrderBook) VWAP(side SideType, size Decimal) (Decimal, error) {
+	if !size.IsPositive() {
+		return Decimal{}, fmt.Errorf("vwap size must be positive, got %s", size)
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if !b.synced {
+		return Decimal{}, ErrOrderBookNotSynced
+	}
+
+	levels, _ := b.takerLevels(side)
+	var cost, filled Decimal
+	for _, level := range levels {
+		fill := size.Sub(filled).Min(level.Quantity)
+		cost = cost.Add(fill.Mul(level.Price))
+		filled = filled.Add(fill)
+		if filled.Equal(size) {
+			return cost.Div(size, vwapPlaces), nil
+		}
+	}
+	return Decimal{}, fmt.Errorf("%w : %s of %s %s available", ErrInsufficientDepth, filled, size, b.symbol)
+}
+
+// takerLevels returns the levels a market order of side fills against and
+// whether they are descending
+func (b *OrderBook) takerLevels(side SideType) ([]OrderBookEntry, bool) {
+	if side == SideTypeSell {
+		return b.bids, true
+	}
+	return b.asks, false
+}
+
+// Snapshot returns a copy of the best levels of each side, every level when
+// levels is zero. nil until synced
+func (b *OrderBook) Snapshot(levels int) *DepthSnapshot {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if !b.synced {
+		return nil
+	}
+	return &DepthSnapshot{
+		Symbol:          b.symbol,
+		LastUpdateId:    b.lastUpdateId,
+		EventTime:       b.eventTime,
+		TransactionTime: b.transactionTime,
+		Bids:            copyLevels(b.bids, levels),
+		Asks:            copyLevels(b.asks, levels),
+	}
+}
+
+func copyLevels(levels []OrderBookEntry, n int) []OrderBookEntry {
+	if n <= 0 || n > len(levels) {
+		n = len(levels)
+	}
+	return append([]OrderBookEntry(nil), levels[:n]...)
+}
@@ -0,0 +1,83 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestOrderBookUnsyncedWhileDisconnected(t *testing.T) {
+	// every snapshot is 100 update ids after the previous one
+	var snapshots int64
+	rest := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := atomic.AddInt64(&snapshots, 1) * 100
+		fmt.Fprintf(w, `{"lastUpdateId":%d,"E":1,"T":1,"bids":[["99","1"]],"asks":[["101","1"]]}`, id)
+	}))
+	defer rest.Close()
+
+	// each connection sends one event continuing the next snapshot once released
+	release := make(chan struct{})
+	var conns int64
+	ws := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		id := atomic.AddInt64(&conns, 1) * 100
+		<-release
+		conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf(
+			`{"e":"depthUpdate","E":2,"T":2,"s":"BTCUSDT","U":%d,"u":%d,"pu":%d,"b":[],"a":[]}`, id-5, id+5, id-10)))
+		<-release
+	}))
+	defer ws.Close()
+
+	c := NewClient("k", "s", WithBaseURL(rest.URL), WithWsBaseURL("ws"+strings.TrimPrefix(ws.URL, "http")+"/ws"))
+	book := c.NewOrderBook("BTCUSDT")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	book.StartContext(ctx)
+	defer book.Stop()
+
+	release <- struct{}{}
+	if err := book.WaitSynced(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// the server closes the first connection
+	release <- struct{}{}
+	waitFor(t, func() bool { return !book.Synced() })
+	waitFor(t, func() bool { return atomic.LoadInt64(&conns) == 2 })
+	time.Sleep(50 * time.Millisecond)
+	if book.Synced() {
+		t.Fatal("book synced after the reconnect without a new snapshot")
+	}
+	if _, ok := book.BestBid(); ok {
+		t.Error("best bid of an unsynced book")
+	}
+
+	release <- struct{}{}
+	waitFor(t, book.Synced)
+	if n := atomic.LoadInt64(&snapshots); n != 2 {
+		t.Errorf("loaded %d snapshots, want 2", n)
+	}
+	close(release)
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
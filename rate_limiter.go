@@ -0,0 +1,327 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type RateLimitPolicy int
+
+const (
+	// RateLimitPolicyBlock waits for the window to reset before sending the request
+	RateLimitPolicyBlock RateLimitPolicy = iota
+	// RateLimitPolicyFailFast returns ErrRateLimitExceeded instead of waiting
+	RateLimitPolicyFailFast
+
+	RateLimitTypeRequestWeight = "REQUEST_WEIGHT"
+	RateLimitTypeOrders        = "ORDERS"
+)
+
+var ErrRateLimitExceeded = errors.New("local rate limit exceeded")
+
+// request weight of each endpoint, endpoints not listed weigh 1.
+// requests whose weight depends on their parameters set request.weight
+var endpointWeights = map[string]int64{
+	endPointExchangeInfo:    1,
+	endPoint24hrTicker:      40, // without symbol
+	endPointDepth:           10, // default limit of 500
+	endPointBalance:         5,
+	endPointAccount:         5,
+	endPointOrder:           1,
+	endPointOrderTest:       1,
+	endPointAllOpenOrders:   1,
+	endPointBatchOrders:     5, // placing and modifying, cancelling weighs 1
+	endPointOrderAmendment:  1,
+	endPointOpenOrder:       1,
+	endPointOpenOrders:      40, // without symbol
+	endPointAllOrders:       5,
+	endPointUserTrades:      5,
+	endPointIncome:          30,
+	endPointPositionRisk:    5,
+	endPointLeverage:        1,
+	endPointLeverageBracket: 1,
+	endPointMarginType:      1,
+	endPointMultiAssets:     30, // reading, changing weighs 1
+	endPointPositionMargin:  1,
+	endPointMarginHistory:   1,
+	endPointListenKey:       1,
+	endPointPositionSide:    30, // reading, changing weighs 1
+}
+
+// klines weight depends on the number of klines requested
+func klinesWeight(limit int64) int64 {
+	switch {
+	case limit > 0 && limit < 100:
+		return 1
+	case limit > 0 && limit < 500:
+		return 2
+	case limit == 0 || limit <= 1000:
+		return 5
+	default:
+		return 10
+	}
+}
+
+// depth weight depends on the number of levels requested
+func depthWeight(limit int64) int64 {
+	switch {
+	case limit > 0 && limit <= 50:
+		return 2
+	case limit > 0 && limit <= 100:
+		return 5
+	case limit == 0 || limit <= 500:
+		return 10
+	default:
+		return 20
+	}
+}
+
+// RateLimitUsage is the usage of a single rate limit window
+type RateLimitUsage struct {
+	Type     string
+	Interval time.Duration
+	Used     int64
+	Limit    int64
+	ResetAt  time.Time
+}
+
+type rateBucket struct {
+	limitType   string
+	interval    time.Duration
+	limit       int64
+	used        int64
+	windowStart time.Time
+}
+
+// roll resets the bucket once its fixed window has passed
+func (b *rateBucket) roll(now time.Time) {
+	windowStart := now.Truncate(b.interval)
+	if windowStart.After(b.windowStart) {
+		b.windowStart = windowStart
+		b.used = 0
+	}
+}
+
+func (b *rateBucket) exceeds(n int64) bool {
+	return n > 0 && b.limit > 0 && b.used+n > b.limit
+}
+
+func (b *rateBucket) resetAt() time.Time {
+	return b.windowStart.Add(b.interval)
+}
+
+// RateLimiter tracks the REQUEST_WEIGHT and ORDERS windows of the account,
+// both from local bookkeeping and from the usage headers sent by binance
+type RateLimiter struct {
+	mu         sync.Mutex
+	policy     RateLimitPolicy
+	buckets    []*rateBucket
+	retryAfter time.Time
+}
+
+func newRateLimiter(policy RateLimitPolicy) *RateLimiter {
+	return &RateLimiter{
+		policy: policy,
+		buckets: []*rateBucket{
+			{limitType: RateLimitTypeRequestWeight, interval: time.Minute, limit: 2400},
+			{limitType: RateLimitTypeOrders, interval: 10 * time.Second, limit: 300},
+			{limitType: RateLimitTypeOrders, interval: time.Minute, limit: 1200},
+		},
+	}
+}
+
+// setLimits replaces the default limits with the ones from exchangeInfo
+func (l *RateLimiter) setLimits(rateLimits []InfoRateLimit) {
+	buckets := make([]*rateBucket, 0)
+	for _, rl := range rateLimits {
+		interval := parseRateInterval(rl.Interval, rl.IntervalNum)
+		if interval == 0 || rl.Limit <= 0 {
+			continue
+		}
+		if rl.RateLimitType != RateLimitTypeRequestWeight && rl.RateLimitType != RateLimitTypeOrders {
+			continue
+		}
+		buckets = append(buckets, &rateBucket{
+			limitType: rl.RateLimitType,
+			interval:  interval,
+			limit:     rl.Limit,
+		})
+	}
+	if len(buckets) == 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, b := range buckets {
+		if old := l.bucket(b.limitType, b.interval); old != nil {
+			b.used = old.used
+			b.windowStart = old.windowStart
+		}
+	}
+	l.buckets = buckets
+}
+
+func (l *RateLimiter) bucket(limitType string, interval time.Duration) *rateBucket {
+	for _, b := range l.buckets {
+		if b.limitType == limitType && b.interval == interval {
+			return b
+		}
+	}
+	return nil
+}
+
+// acquire reserves weight and order count for a request, waiting or
+// failing according to the policy when a window is exhausted
+func (l *RateLimiter) acquire(ctx context.Context, weight, orders int64) error {
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		waitUntil := l.retryAfter
+		reason := "retry after"
+		if !now.Before(waitUntil) {
+			waitUntil = time.Time{}
+			for _, b := range l.buckets {
+				b.roll(now)
+				n := weight
+				if b.limitType == RateLimitTypeOrders {
+					n = orders
+				}
+				if b.exceeds(n) && b.resetAt().After(waitUntil) {
+					waitUntil = b.resetAt()
+					reason = fmt.Sprintf("%s %d/%d per %s", b.limitType, b.used, b.limit, b.interval)
+				}
+			}
+		}
+		if waitUntil.IsZero() {
+			for _, b := range l.buckets {
+				if b.limitType == RateLimitTypeOrders {
+					b.used += orders
+				} else {
+					b.used += weight
+				}
+			}
+			l.mu.Unlock()
+			return nil
+		}
+		l.mu.Unlock()
+
+		if l.policy == RateLimitPolicyFailFast {
+			return fmt.Errorf("%w : %s, resets at %s", ErrRateLimitExceeded, reason, waitUntil.Format(time.RFC3339))
+		}
+		timer := time.NewTimer(time.Until(waitUntil))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// update syncs the buckets with the usage headers of a response and
+// honours Retry-After on 429 and 418 responses
+func (l *RateLimiter) update(res *http.Response) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	for key, values := range res.Header {
+		key = strings.ToLower(key)
+		limitType := ""
+		suffix := ""
+		if strings.HasPrefix(key, "x-mbx-used-weight-") {
+			limitType = RateLimitTypeRequestWeight
+			suffix = strings.TrimPrefix(key, "x-mbx-used-weight-")
+		} else if strings.HasPrefix(key, "x-mbx-order-count-") {
+			limitType = RateLimitTypeOrders
+			suffix = strings.TrimPrefix(key, "x-mbx-order-count-")
+		} else {
+			continue
+		}
+		b := l.bucket(limitType, parseHeaderInterval(suffix))
+		if b == nil || len(values) == 0 {
+			continue
+		}
+		b.roll(now)
+		if used := ParseInt(values[0]); used > b.used {
+			b.used = used
+		}
+	}
+
+	if res.StatusCode == http.StatusTooManyRequests || res.StatusCode == http.StatusTeapot {
+		retryAfter := now.Add(time.Minute)
+		if secs, err := strconv.ParseInt(res.Header.Get("Retry-After"), 10, 64); err == nil && secs > 0 {
+			retryAfter = now.Add(time.Duration(secs) * time.Second)
+		}
+		if retryAfter.After(l.retryAfter) {
+			l.retryAfter = retryAfter
+		}
+	}
+}
+
+func (l *RateLimiter) usage() []RateLimitUsage {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	usage := make([]RateLimitUsage, 0, len(l.buckets))
+	for _, b := range l.buckets {
+		b.roll(now)
+		usage = append(usage, RateLimitUsage{
+			Type:     b.limitType,
+			Interval: b.interval,
+			Used:     b.used,
+			Limit:    b.limit,
+			ResetAt:  b.resetAt(),
+		})
+	}
+	return usage
+}
+
+// parseRateInterval converts exchangeInfo intervals (SECOND, MINUTE, ...) to a duration
+func parseRateInterval(interval string, num int64) time.Duration {
+	units := map[string]time.Duration{
+		"SECOND": time.Second,
+		"MINUTE": time.Minute,
+		"HOUR":   time.Hour,
+		"DAY":    24 * time.Hour,
+	}
+	return units[interval] * time.Duration(num)
+}
+
+// parseHeaderInterval converts header suffixes (10s, 1m, ...) to a duration
+func parseHeaderInterval(suffix string) time.Duration {
+	if len(suffix) < 2 {
+		return 0
+	}
+	units := map[byte]time.Duration{
+		's': time.Second,
+		'm': time.Minute,
+		'h': time.Hour,
+		'd': 24 * time.Hour,
+	}
+	num := ParseInt(suffix[:len(suffix)-1])
+	return units[suffix[len(suffix)-1]] * time.Duration(num)
+}
+
+// RateLimitUsage returns the current usage of every rate limit window
+func (c *Client) RateLimitUsage() []RateLimitUsage {
+	return c.limiter.usage()
+}
+
+// RetryAfter returns the time until which binance asked us to back off, zero if not limited
+func (c *Client) RetryAfter() time.Time {
+	c.limiter.mu.Lock()
+	defer c.limiter.mu.Unlock()
+	if time.Now().After(c.limiter.retryAfter) {
+		return time.Time{}
+	}
+	return c.limiter.retryAfter
+}
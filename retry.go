@@ -0,0 +1,128 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy decides how failed requests are retried. Only idempotent
+// requests are retried : every GET, and order placement when the order
+// carries a newClientOrderId (binance rejects a duplicate id).
+type RetryPolicy struct {
+	MaxAttempts          int           // including the first attempt, <= 1 disables retries
+	BaseDelay            time.Duration // delay before the first retry, doubled on every attempt
+	MaxDelay             time.Duration
+	RetryableStatusCodes []int
+	RetryableCodes       []int64 // binance error codes
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   200 * time.Millisecond,
+	MaxDelay:    5 * time.Second,
+	RetryableStatusCodes: []int{
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+	},
+	RetryableCodes: []int64{
+		-1000, // unknown error
+		-1001, // internal error, disconnected
+		-1007, // timeout waiting for backend
+	},
+}
+
+// ErrOrderStatusUnknown is matched by OrderStatusUnknownError
+var ErrOrderStatusUnknown = errors.New("order status unknown")
+
+// OrderStatusUnknownError is returned when an order placement timed out or
+// failed after reaching binance, or when a retry of such a placement failed
+// (e.g. with ErrDuplicateClientOrderId). the order may or may not have been
+// placed, query it by ClientOrderId before placing it again.
+type OrderStatusUnknownError struct {
+	Symbol        string
+	ClientOrderId string
+	Err           error
+}
+
+func (e *OrderStatusUnknownError) Error() string {
+	return fmt.Sprintf("order status unknown (symbol %s, clientOrderId %s) : %v", e.Symbol, e.ClientOrderId, e.Err)
+}
+
+func (e *OrderStatusUnknownError) Is(target error) bool {
+	return target == ErrOrderStatusUnknown
+}
+
+func (e *OrderStatusUnknownError) Unwrap() error {
+	return e.Err
+}
+
+// backoff returns an exponential delay with full jitter for the given attempt (1 based)
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay << (attempt - 1)
+	if delay <= 0 || (p.MaxDelay > 0 && delay > p.MaxDelay) {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+func (p RetryPolicy) isRetryable(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		for _, code := range p.RetryableCodes {
+			if apiErr.Code == code {
+				return true
+			}
+		}
+		for _, status := range p.RetryableStatusCodes {
+			if apiErr.StatusCode == status {
+				return true
+			}
+		}
+		return false
+	}
+	if errors.Is(err, ErrRateLimitExceeded) || errors.Is(err, context.Canceled) {
+		return false
+	}
+	// transport errors : resets, timeouts, unexpected eof
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+}
+
+// isStatusUnknown reports whether a failed request, once sent, may still have been executed by binance
+func isStatusUnknown(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= http.StatusInternalServerError || apiErr.Code == -1007 || apiErr.Code == -1001
+	}
+	if errors.Is(err, ErrRateLimitExceeded) {
+		return false
+	}
+	// the request never left when the connection could not be opened
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return false
+	}
+	return true
+}
+
+func (r *request) isOrderPlacement() bool {
+	return r.method == http.MethodPost && r.endpoint == endPointOrder
+}
+
+func (r *request) isIdempotent() bool {
+	if r.method == http.MethodGet {
+		return true
+	}
+	return r.isOrderPlacement() && r.query.Get(key_NEW_CLIENT_ORDER_ID) != ""
+}
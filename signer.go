@@ -0,0 +1,103 @@
+package binance
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+)
+
+// Signer signs the query string of signed requests
+type Signer interface {
+	Sign(payload string) (string, error)
+}
+
+// HMACSigner signs with HMAC-SHA256 and a shared secret key (hex encoded signature)
+type HMACSigner struct {
+	secretKey string
+}
+
+func NewHMACSigner(secretKey string) *HMACSigner {
+	return &HMACSigner{secretKey: secretKey}
+}
+
+func (s *HMACSigner) Sign(payload string) (string, error) {
+	return computeSignature(payload, s.secretKey), nil
+}
+
+// Ed25519Signer signs with an Ed25519 private key (base64 encoded signature)
+type Ed25519Signer struct {
+	key ed25519.PrivateKey
+}
+
+func NewEd25519Signer(key ed25519.PrivateKey) *Ed25519Signer {
+	return &Ed25519Signer{key: key}
+}
+
+// NewEd25519SignerFromPEM loads an unencrypted PKCS#8 "PRIVATE KEY" pem block
+func NewEd25519SignerFromPEM(pemData []byte) (*Ed25519Signer, error) {
+	key, err := parsePEMPrivateKey(pemData)
+	if err != nil {
+		return nil, err
+	}
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("pem does not contain an ed25519 private key (got %T)", key)
+	}
+	return NewEd25519Signer(edKey), nil
+}
+
+func (s *Ed25519Signer) Sign(payload string) (string, error) {
+	sig := ed25519.Sign(s.key, []byte(payload))
+	return base64.StdEncoding.EncodeToString(sig), nil
+}
+
+// RSASigner signs with RSASSA-PKCS1-v1_5 over SHA-256 (base64 encoded signature)
+type RSASigner struct {
+	key *rsa.PrivateKey
+}
+
+func NewRSASigner(key *rsa.PrivateKey) *RSASigner {
+	return &RSASigner{key: key}
+}
+
+// NewRSASignerFromPEM loads an unencrypted PKCS#1 "RSA PRIVATE KEY" or PKCS#8 "PRIVATE KEY" pem block
+func NewRSASignerFromPEM(pemData []byte) (*RSASigner, error) {
+	key, err := parsePEMPrivateKey(pemData)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("pem does not contain an rsa private key (got %T)", key)
+	}
+	return NewRSASigner(rsaKey), nil
+}
+
+func (s *RSASigner) Sign(payload string) (string, error) {
+	hashed := sha256.Sum256([]byte(payload))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, hashed[:])
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(sig), nil
+}
+
+func parsePEMPrivateKey(pemData []byte) (interface{}, error) {
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return nil, fmt.Errorf("no pem block found")
+	}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		return x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	return nil, fmt.Errorf("unsupported pem block type %q", block.Type)
+}
@@ -0,0 +1,600 @@
+package binance
+
+/* many streams multiplexed over combined connections */
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	maxStreamsPerConn = 200 // binance limit of a single connection
+	hubChannelSize    = 100
+	wsRequestTimeout  = 10 * time.Second
+)
+
+var (
+	ErrHubStarted = errors.New("stream hub already started")
+	ErrHubStopped = errors.New("stream hub stopped")
+)
+
+const TickerArrStreamName = "!ticker@arr"
+
+func KlineStreamName(symbol, interval string) string {
+	return fmt.Sprintf("%s@kline_%s", strings.ToLower(symbol), interval)
+}
+
+func DepthStreamName(symbol string, level int) string {
+	return fmt.Sprintf("%s@depth%d", strings.ToLower(symbol), level)
+}
+
+type hubSubscription struct {
+	deliver func(data []byte)
+	close   func()
+}
+
+type hubConn struct {
+	streams []string
+	dialed  []string // streams in the url of the last dial
+	wss     *WebSocketStream
+}
+
+// StreamHub delivers many streams over combined connections of up to
+// maxStreamsPerConn streams each, every subscription gets its own channel.
+// messages are dropped when a channel is full, so a slow subscriber
+// doesn't hold back the others. once started, streams are subscribed and
+// unsubscribed on the open connections and a reconnect resubscribes the
+// current set
+type StreamHub struct {
+	c      *Client
+	nextId int64
+
+	mu      sync.Mutex
+	streams []string // in subscription order, until started
+	subs    map[string][]*hubSubscription
+	conns   []*hubConn
+	pending map[int64]chan *jsonStreamEnvelope // requests waiting for a response
+	events  chan StreamEvent
+	started bool
+	done    bool
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+func (c *Client) NewStreamHub() *StreamHub {
+	return &StreamHub{
+		c:       c,
+		subs:    make(map[string][]*hubSubscription),
+		pending: make(map[int64]chan *jsonStreamEnvelope),
+		events:  make(chan StreamEvent, wsEventsBuffer),
+	}
+}
+
+func (h *StreamHub) SubscribeKlines(symbol, interval string) (<-chan *Kline, error) {
+	return h.SubscribeKlinesContext(context.Background(), symbol, interval)
+}
+
+func (h *StreamHub) SubscribeKlinesContext(ctx context.Context, symbol, interval string) (<-chan *Kline, error) {
+	stream := KlineStreamName(symbol, interval)
+	out := make(chan *Kline, hubChannelSize)
+	err := h.subscribe(ctx, stream, &hubSubscription{
+		deliver: func(data []byte) {
+			kline, err := parseKlineEvent(data)
+			if err != nil {
+				h.c.logger.Error("error in parsing kline", "err", err, "data", string(data))
+				return
+			}
+			select {
+			case out <- kline:
+			default:
+				h.c.logger.Warn("error in stream hub : out channel is full", "stream", stream)
+			}
+		},
+		close: func() { close(out) },
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (h *StreamHub) SubscribeDepth(symbol string, level int) (<-chan *OrderBookEvent, error) {
+	return h.SubscribeDepthContext(context.Background(), symbol, level)
+}
+
+func (h *StreamHub) SubscribeDepthContext(ctx context.Context, symbol string, level int) (<-chan *OrderBookEvent, error) {
+	stream := DepthStreamName(symbol, level)
+	out := make(chan *OrderBookEvent, hubChannelSize)
+	err := h.subscribe(ctx, stream, &hubSubscription{
+		deliver: func(data []byte) {
+			event, err := parseDepthEvent(data)
+			if err != nil {
+				h.c.logger.Error("error in parsing depth event", "err", err, "data", string(data))
+				return
+			}
+			select {
+			case out <- event:
+			default:
+				h.c.logger.Warn("error in stream hub : out channel is full", "stream", stream)
+			}
+		},
+		close: func() { close(out) },
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (h *StreamHub) SubscribeTickers() (<-chan *PriceTicker, error) {
+	return h.SubscribeTickersContext(context.Background())
+}
+
+// SubscribeTickersContext subscribes to the 24hr tickers of every symbol
+func (h *StreamHub) SubscribeTickersContext(ctx context.Context) (<-chan *PriceTicker, error) {
+	stream := TickerArrStreamName
+	out := make(chan *PriceTicker, hubChannelSize)
+	err := h.subscribe(ctx, stream, &hubSubscription{
+		deliver: func(data []byte) {
+			tickers, err := parseTickerEvents(data)
+			if err != nil {
+				h.c.logger.Error("error in parsing ws ticker", "err", err)
+				return
+			}
+			for _, ticker := range tickers {
+				select {
+				case out <- ticker:
+				default:
+					h.c.logger.Warn("error in stream hub : out channel is full", "stream", stream, "symbol", ticker.Symbol)
+				}
+			}
+		},
+		close: func() { close(out) },
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// subscribe registers a subscription, once the hub is started new streams
+// are subscribed on a connection with room or on a new connection
+func (h *StreamHub) subscribe(ctx context.Context, stream string, sub *hubSubscription) error {
+	h.mu.Lock()
+	if h.done {
+		h.mu.Unlock()
+		return ErrHubStopped
+	}
+	if subs, ok := h.subs[stream]; ok || !h.started {
+		if !ok {
+			h.streams = append(h.streams, stream)
+		}
+		h.subs[stream] = append(subs, sub)
+		h.mu.Unlock()
+		return nil
+	}
+
+	h.subs[stream] = []*hubSubscription{sub}
+	var conn *hubConn
+	for _, c := range h.conns {
+		if len(c.streams) < maxStreamsPerConn {
+			conn = c
+			break
+		}
+	}
+	if conn == nil {
+		// the new connection subscribes through its url
+		h.startConn([]string{stream})
+		h.mu.Unlock()
+		return nil
+	}
+	conn.streams = append(conn.streams, stream)
+	h.mu.Unlock()
+
+	_, err := h.request(ctx, conn, "SUBSCRIBE", []string{stream})
+	if errors.Is(err, errStreamNotConnected) {
+		// subscribed by the next connect
+		h.c.logger.Debug("stream hub connection is down, subscribing on connect", "stream", stream)
+		return nil
+	}
+	if err != nil {
+		// without a response binance may still have applied the subscription
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) && h.confirmSubscribed(conn, stream) {
+			h.c.logger.Warn("stream subscribed without a response", "err", err, "stream", stream)
+			return nil
+		}
+		h.c.logger.Error("error in subscribing stream", "err", err, "stream", stream)
+		h.mu.Lock()
+		h.removeStream(stream)
+		h.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// confirmSubscribed checks with LIST_SUBSCRIPTIONS whether a SUBSCRIBE which
+// got no response was applied. binance handles the requests of a connection
+// in order, so the list includes the stream if it was. when the list fails
+// too, an UNSUBSCRIBE is sent so the stream does not stay subscribed unseen
+func (h *StreamHub) confirmSubscribed(conn *hubConn, stream string) bool {
+	ctx, cancel := context.WithTimeout(h.ctx, wsRequestTimeout)
+	defer cancel()
+	streams, err := h.listConn(ctx, conn)
+	if err != nil {
+		req := &jsonStreamRequest{Method: "UNSUBSCRIBE", Params: []string{stream}, Id: atomic.AddInt64(&h.nextId, 1)}
+		if err := conn.wss.send(ctx, req); err != nil && !errors.Is(err, errStreamNotConnected) {
+			h.c.logger.Error("error in unsubscribing unconfirmed stream", "err", err, "stream", stream)
+		}
+		return false
+	}
+	for _, s := range streams {
+		if s == stream {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *StreamHub) Unsubscribe(streams ...string) error {
+	return h.UnsubscribeContext(context.Background(), streams...)
+}
+
+// UnsubscribeContext unsubscribes streams by name (see KlineStreamName,
+// DepthStreamName) and closes the channels of their subscriptions
+func (h *StreamHub) UnsubscribeContext(ctx context.Context, streams ...string) error {
+	h.mu.Lock()
+	byConn := make(map[*hubConn][]string)
+	for _, stream := range streams {
+		if _, ok := h.subs[stream]; !ok {
+			continue
+		}
+		if conn := h.removeStream(stream); conn != nil {
+			byConn[conn] = append(byConn[conn], stream)
+		}
+	}
+	h.mu.Unlock()
+
+	for conn, streams := range byConn {
+		_, err := h.request(ctx, conn, "UNSUBSCRIBE", streams)
+		if errors.Is(err, errStreamNotConnected) {
+			continue // left out of the url of the next connect
+		}
+		if err != nil {
+			h.c.logger.Error("error in unsubscribing streams", "err", err, "streams", streams)
+			return err
+		}
+	}
+	return nil
+}
+
+// removeStream closes the subscriptions of a stream and returns its connection
+func (h *StreamHub) removeStream(stream string) *hubConn {
+	for _, sub := range h.subs[stream] {
+		sub.close()
+	}
+	delete(h.subs, stream)
+	h.streams = removeString(h.streams, stream)
+	for _, conn := range h.conns {
+		for _, s := range conn.streams {
+			if s == stream {
+				conn.streams = removeString(conn.streams, stream)
+				return conn
+			}
+		}
+	}
+	return nil
+}
+
+func removeString(list []string, s string) []string {
+	res := make([]string, 0, len(list))
+	for _, item := range list {
+		if item != s {
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
+func (h *StreamHub) ListSubscriptions() ([]string, error) {
+	return h.ListSubscriptionsContext(context.Background())
+}
+
+// ListSubscriptionsContext returns the streams binance has subscribed on every
+// connection of the hub, or the registered streams before the hub is started
+func (h *StreamHub) ListSubscriptionsContext(ctx context.Context) ([]string, error) {
+	h.mu.Lock()
+	if !h.started {
+		streams := append([]string(nil), h.streams...)
+		h.mu.Unlock()
+		return streams, nil
+	}
+	conns := append([]*hubConn(nil), h.conns...)
+	h.mu.Unlock()
+
+	streams := make([]string, 0)
+	for _, conn := range conns {
+		list, err := h.listConn(ctx, conn)
+		if err != nil {
+			return nil, err
+		}
+		streams = append(streams, list...)
+	}
+	return streams, nil
+}
+
+// listConn returns the streams binance has subscribed on one connection
+func (h *StreamHub) listConn(ctx context.Context, conn *hubConn) ([]string, error) {
+	data, err := h.request(ctx, conn, "LIST_SUBSCRIPTIONS", nil)
+	if err != nil {
+		return nil, err
+	}
+	var list []string
+	err = json.Unmarshal(data, &list)
+	if err != nil {
+		h.c.logger.Error("error in parsing subscriptions", "err", err, "data", string(data))
+		return nil, err
+	}
+	return list, nil
+}
+
+type jsonStreamRequest struct {
+	Method string   `json:"method"`
+	Params []string `json:"params,omitempty"`
+	Id     int64    `json:"id"`
+}
+
+type jsonStreamError struct {
+	Code    int64  `json:"code"`
+	Message string `json:"msg"`
+}
+
+// request sends a method frame on a connection and waits for the response with the same id
+func (h *StreamHub) request(ctx context.Context, conn *hubConn, method string, params []string) (json.RawMessage, error) {
+	id := atomic.AddInt64(&h.nextId, 1)
+	resCh := make(chan *jsonStreamEnvelope, 1)
+	h.mu.Lock()
+	h.pending[id] = resCh
+	h.mu.Unlock()
+	defer func() {
+		h.mu.Lock()
+		delete(h.pending, id)
+		h.mu.Unlock()
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, wsRequestTimeout)
+	defer cancel()
+	err := conn.wss.send(ctx, &jsonStreamRequest{Method: method, Params: params, Id: id})
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case res := <-resCh:
+		if res.Error != nil {
+			return nil, &APIError{Code: res.Error.Code, Message: res.Error.Message, Endpoint: method}
+		}
+		return res.Result, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("no response to %s : %w", method, ctx.Err())
+	}
+}
+
+func (h *StreamHub) Start() error {
+	return h.StartContext(context.Background())
+}
+
+// StartContext opens the connections, every subscription channel and the
+// events channel are closed once ctx is cancelled or the hub is stopped
+func (h *StreamHub) StartContext(ctx context.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.started {
+		return ErrHubStarted
+	}
+	h.started = true
+	h.ctx, h.cancel = context.WithCancel(ctx)
+
+	for i := 0; i < len(h.streams); i += maxStreamsPerConn {
+		end := i + maxStreamsPerConn
+		if end > len(h.streams) {
+			end = len(h.streams)
+		}
+		h.startConn(append([]string(nil), h.streams[i:end]...))
+	}
+	h.streams = nil
+
+	go func() {
+		<-h.ctx.Done()
+		h.mu.Lock()
+		h.done = true
+		h.mu.Unlock()
+		h.wg.Wait()
+		h.finish()
+	}()
+	return nil
+}
+
+// startConn opens a connection for streams, called with h.mu held
+func (h *StreamHub) startConn(streams []string) {
+	conn := &hubConn{
+		streams: streams,
+		wss:     newWebSocketStream("", h.c.logger),
+	}
+	conn.wss.urlFunc = func(ctx context.Context) (string, error) {
+		return h.connURL(conn), nil
+	}
+	conn.wss.onConnect = func(ctx context.Context) {
+		h.syncStreams(ctx, conn)
+	}
+	h.conns = append(h.conns, conn)
+
+	h.wg.Add(2)
+	connCtx := conn.wss.start(h.ctx)
+	go func() {
+		defer h.wg.Done()
+		h.run(connCtx, conn)
+	}()
+	go func() {
+		defer h.wg.Done()
+		h.forwardEvents(conn)
+	}()
+}
+
+// connURL subscribes the current streams of a connection on every connect
+func (h *StreamHub) connURL(conn *hubConn) string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	conn.dialed = append([]string(nil), conn.streams...)
+	if len(conn.streams) == 0 {
+		return strings.TrimSuffix(h.c.wsStreamURL, "?streams=")
+	}
+	return h.c.wsStreamURL + strings.Join(conn.streams, "/")
+}
+
+// syncStreams sends the streams changed while the connection was dialing,
+// the responses are not waited for as they are read by the caller
+func (h *StreamHub) syncStreams(ctx context.Context, conn *hubConn) {
+	h.mu.Lock()
+	subscribe := missingStreams(conn.streams, conn.dialed)
+	unsubscribe := missingStreams(conn.dialed, conn.streams)
+	conn.dialed = nil
+	h.mu.Unlock()
+
+	for method, streams := range map[string][]string{"SUBSCRIBE": subscribe, "UNSUBSCRIBE": unsubscribe} {
+		if len(streams) == 0 {
+			continue
+		}
+		req := &jsonStreamRequest{Method: method, Params: streams, Id: atomic.AddInt64(&h.nextId, 1)}
+		if err := conn.wss.send(ctx, req); err != nil {
+			h.c.logger.Error("error in syncing streams on connect", "err", err, "method", method, "streams", streams)
+		}
+	}
+}
+
+// missingStreams returns the streams of list which are not in other
+func missingStreams(list, other []string) []string {
+	missing := make([]string, 0)
+	for _, stream := range list {
+		found := false
+		for _, s := range other {
+			if s == stream {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, stream)
+		}
+	}
+	return missing
+}
+
+func (h *StreamHub) Stop() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.cancel != nil {
+		h.cancel()
+	}
+}
+
+// Events reports reconnects of every connection of the hub, Streams of an
+// event are the streams of its connection
+func (h *StreamHub) Events() <-chan StreamEvent {
+	return h.events
+}
+
+// jsonStreamEnvelope is either a stream message or the response to a request
+type jsonStreamEnvelope struct {
+	Stream string           `json:"stream"`
+	Data   json.RawMessage  `json:"data"`
+	Id     *int64           `json:"id"`
+	Result json.RawMessage  `json:"result"`
+	Error  *jsonStreamError `json:"error"`
+}
+
+func (h *StreamHub) run(ctx context.Context, conn *hubConn) {
+	defer conn.wss.finish()
+	for {
+		msg, err := conn.wss.getNextMessage(ctx)
+		if err != nil {
+			return
+		}
+		var envelope jsonStreamEnvelope
+		err = json.Unmarshal(msg, &envelope)
+		if err != nil {
+			h.c.logger.Error("error in parsing combined stream message", "err", err, "data", string(msg))
+			continue
+		}
+		if envelope.Stream == "" && (envelope.Id != nil || envelope.Error != nil) {
+			h.respond(&envelope)
+			continue
+		}
+		h.route(envelope.Stream, envelope.Data)
+	}
+}
+
+func (h *StreamHub) respond(res *jsonStreamEnvelope) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if res.Id == nil {
+		h.c.logger.Error("error response without id on stream hub", "code", res.Error.Code, "msg", res.Error.Message)
+		return
+	}
+	resCh, ok := h.pending[*res.Id]
+	if !ok {
+		if res.Error != nil {
+			h.c.logger.Error("error response on stream hub", "id", *res.Id, "code", res.Error.Code, "msg", res.Error.Message)
+		}
+		return
+	}
+	select {
+	case resCh <- res:
+	default:
+	}
+}
+
+func (h *StreamHub) route(stream string, data []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	subs, ok := h.subs[stream]
+	if !ok {
+		h.c.logger.Debug("message of unknown stream", "stream", stream)
+		return
+	}
+	for _, sub := range subs {
+		sub.deliver(data)
+	}
+}
+
+func (h *StreamHub) forwardEvents(conn *hubConn) {
+	for event := range conn.wss.events {
+		h.mu.Lock()
+		event.Streams = append([]string(nil), conn.streams...)
+		h.mu.Unlock()
+		select {
+		case h.events <- event:
+		default:
+			h.c.logger.Warn("stream hub events channel is full, dropping event", "type", event.Type)
+		}
+	}
+}
+
+// finish closes the subscription channels once every connection has ended
+func (h *StreamHub) finish() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, subs := range h.subs {
+		for _, sub := range subs {
+			sub.close()
+		}
+	}
+	h.subs = make(map[string][]*hubSubscription)
+	close(h.events)
+}
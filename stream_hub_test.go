@@ -0,0 +1,93 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// newSilentHubServer serves combined streams and never answers SUBSCRIBE,
+// it applies the subscription when apply is set
+func newSilentHubServer(t *testing.T, apply bool) string {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		streams := strings.Split(r.URL.Query().Get("streams"), "/")
+		for {
+			var req jsonStreamRequest
+			if err := conn.ReadJSON(&req); err != nil {
+				return
+			}
+			switch req.Method {
+			case "SUBSCRIBE":
+				if apply {
+					streams = append(streams, req.Params...)
+				}
+			case "UNSUBSCRIBE":
+				for _, p := range req.Params {
+					streams = removeString(streams, p)
+				}
+				conn.WriteJSON(map[string]interface{}{"result": nil, "id": req.Id})
+			case "LIST_SUBSCRIPTIONS":
+				conn.WriteJSON(map[string]interface{}{"result": streams, "id": req.Id})
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return "ws" + strings.TrimPrefix(srv.URL, "http") + "/ws"
+}
+
+func TestStreamHubSubscribeWithoutResponse(t *testing.T) {
+	for _, applied := range []bool{true, false} {
+		name := "not applied"
+		if applied {
+			name = "applied"
+		}
+		t.Run(name, func(t *testing.T) {
+			h := NewClient("k", "s", WithWsBaseURL(newSilentHubServer(t, applied))).NewStreamHub()
+			if _, err := h.SubscribeKlines("BTCUSDT", "1m"); err != nil {
+				t.Fatal(err)
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if err := h.StartContext(ctx); err != nil {
+				t.Fatal(err)
+			}
+			select {
+			case event := <-h.Events():
+				if event.Type != StreamEventConnected {
+					t.Fatalf("event = %s, want CONNECTED", event.Type)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("hub did not connect")
+			}
+
+			subCtx, subCancel := context.WithTimeout(ctx, 200*time.Millisecond)
+			defer subCancel()
+			_, err := h.SubscribeKlinesContext(subCtx, "ETHUSDT", "1m")
+
+			h.mu.Lock()
+			_, kept := h.subs[KlineStreamName("ETHUSDT", "1m")]
+			h.mu.Unlock()
+			if applied {
+				if err != nil || !kept {
+					t.Errorf("err = %v, kept = %v, want the applied subscription kept", err, kept)
+				}
+				return
+			}
+			if !errors.Is(err, context.DeadlineExceeded) || kept {
+				t.Errorf("err = %v, kept = %v, want the subscription removed", err, kept)
+			}
+		})
+	}
+}
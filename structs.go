@@ -7,30 +7,30 @@ type Kline struct {
 	EventTime           int64
 	OpenTime            int64
 	CloseTime           int64
-	OpenPrice           float64
-	HighPrice           float64
-	LowPrice            float64
-	ClosePrice          float64
+	OpenPrice           Decimal
+	HighPrice           Decimal
+	LowPrice            Decimal
+	ClosePrice          Decimal
 	TradeCount          int64
-	BaseVolume          float64
-	QuoteVolume         float64
-	TakerBuyBaseVolume  float64
-	TakerBuyQuoteVolume float64
+	BaseVolume          Decimal
+	QuoteVolume         Decimal
+	TakerBuyBaseVolume  Decimal
+	TakerBuyQuoteVolume Decimal
 	IsFinal             bool
 }
 
 type PriceTicker struct {
 	Symbol             string
-	PriceChange        float64
-	PriceChangePercent float64
-	WeightedAvgPrice   float64
-	LastPrice          float64
-	LastQuantity       float64
-	OpenPrice          float64
-	HighPrice          float64
-	LowPrice           float64
-	BaseVolume         float64
-	QuoteVolume        float64
+	PriceChange        Decimal
+	PriceChangePercent Decimal
+	WeightedAvgPrice   Decimal
+	LastPrice          Decimal
+	LastQuantity       Decimal
+	OpenPrice          Decimal
+	HighPrice          Decimal
+	LowPrice           Decimal
+	BaseVolume         Decimal
+	QuoteVolume        Decimal
 	OpenTime           int64
 	CloseTime          int64
 	FirstTradeId       int64
@@ -39,15 +39,29 @@ type PriceTicker struct {
 }
 
 type OrderBookEntry struct {
-	Price    float64
-	Quantity float64
+	Price    Decimal
+	Quantity Decimal
 }
 
 type OrderBookEvent struct {
-	EventType       string
+	EventType         string
+	EventTime         int64
+	TransactionTime   int64
+	Symbol            string
+	FirstUpdateId     int64 // U
+	FinalUpdateId     int64 // u
+	PrevFinalUpdateId int64 // pu, FinalUpdateId of the previous event
+	Bids              []OrderBookEntry
+	Asks              []OrderBookEntry
+}
+
+// DepthSnapshot is the order book at LastUpdateId, bids from the highest
+// price and asks from the lowest
+type DepthSnapshot struct {
+	Symbol          string
+	LastUpdateId    int64
 	EventTime       int64
 	TransactionTime int64
-	Symbol          string
 	Bids            []OrderBookEntry
 	Asks            []OrderBookEntry
 }
@@ -55,109 +69,403 @@ type OrderBookEvent struct {
 type CoinBalance struct {
 	AccountAlias       string
 	Asset              string
-	Balance            float64
-	CrossWalletBalance float64
-	CrossUnPnl         float64
-	AvailableBalance   float64
-	MaxWithdrawAmount  float64
+	Balance            Decimal
+	CrossWalletBalance Decimal
+	CrossUnPnl         Decimal
+	AvailableBalance   Decimal
+	MaxWithdrawAmount  Decimal
 	MarginAvailable    bool
 	UpdateTime         int64
 }
 
+// the typed orders accept every order option, the combinations binance
+// rejects (e.g. ClosePosition on a LIMIT order) fail validation before sending
+
 type LimitOrder struct {
-	Symbol      string
-	Side        SideType
-	TimeInForce TimeInForceType
-	Quantity    float64
-	Price       float64
+	Symbol                  string
+	Side                    SideType
+	Quantity                Decimal
+	Price                   Decimal
+	PriceMatch              PriceMatchType // instead of Price
+	PositionSide            PositionSideType
+	TimeInForce             TimeInForceType
+	GoodTillDate            int64 // milliseconds, with TimeInForceTypeGTD
+	SelfTradePreventionMode SelfTradePreventionMode
+	ReduceOnly              bool
+	ClosePosition           bool
+	WorkingType             WorkingType
+	PriceProtect            bool
+	MarkPrice               Decimal // for the PERCENT_PRICE and MIN_NOTIONAL checks, skipped when zero
 }
 
 type MarketOrder struct {
-	Symbol   string
-	Side     SideType
-	Quantity float64
+	Symbol                  string
+	Side                    SideType
+	Quantity                Decimal
+	PositionSide            PositionSideType
+	TimeInForce             TimeInForceType
+	GoodTillDate            int64 // milliseconds, with TimeInForceTypeGTD
+	SelfTradePreventionMode SelfTradePreventionMode
+	ReduceOnly              bool
+	ClosePosition           bool
+	WorkingType             WorkingType
+	PriceProtect            bool
+	MarkPrice               Decimal // for the PERCENT_PRICE and MIN_NOTIONAL checks, skipped when zero
 }
 
 type StopOrder struct {
-	Symbol      string
-	Side        SideType
-	TimeInForce TimeInForceType
-	Quantity    float64
-	Price       float64
-	StopPrice   float64
-	ReduceOnly  bool
+	Symbol                  string
+	Side                    SideType
+	Quantity                Decimal
+	Price                   Decimal
+	PriceMatch              PriceMatchType // instead of Price
+	StopPrice               Decimal
+	PositionSide            PositionSideType
+	TimeInForce             TimeInForceType
+	GoodTillDate            int64 // milliseconds, with TimeInForceTypeGTD
+	SelfTradePreventionMode SelfTradePreventionMode
+	ReduceOnly              bool
+	ClosePosition           bool
+	WorkingType             WorkingType
+	PriceProtect            bool
+	MarkPrice               Decimal // for the PERCENT_PRICE and MIN_NOTIONAL checks, skipped when zero
 }
 
 type TakeProfitOrder struct {
-	Symbol     string
-	Side       SideType
-	Quantity   float64
-	Price      float64
-	StopPrice  float64
-	ReduceOnly bool
+	Symbol                  string
+	Side                    SideType
+	Quantity                Decimal
+	Price                   Decimal
+	PriceMatch              PriceMatchType // instead of Price
+	StopPrice               Decimal
+	PositionSide            PositionSideType
+	TimeInForce             TimeInForceType
+	GoodTillDate            int64 // milliseconds, with TimeInForceTypeGTD
+	SelfTradePreventionMode SelfTradePreventionMode
+	ReduceOnly              bool
+	ClosePosition           bool
+	WorkingType             WorkingType
+	PriceProtect            bool
+	MarkPrice               Decimal // for the PERCENT_PRICE and MIN_NOTIONAL checks, skipped when zero
 }
 
 type StopMarketOrder struct {
-	Symbol     string
-	Side       SideType
-	StopPrice  float64
-	Quantity   float64
-	ReduceOnly bool
+	Symbol                  string
+	Side                    SideType
+	StopPrice               Decimal
+	Quantity                Decimal // zero with ClosePosition
+	PositionSide            PositionSideType
+	TimeInForce             TimeInForceType
+	GoodTillDate            int64 // milliseconds, with TimeInForceTypeGTD
+	SelfTradePreventionMode SelfTradePreventionMode
+	ReduceOnly              bool
+	ClosePosition           bool
+	WorkingType             WorkingType
+	PriceProtect            bool
+	MarkPrice               Decimal // for the PERCENT_PRICE and MIN_NOTIONAL checks, skipped when zero
 }
 
 type TakeProfitMarketOrder struct {
-	Symbol     string
-	Side       SideType
-	StopPrice  float64
-	Quantity   float64
-	ReduceOnly bool
+	Symbol                  string
+	Side                    SideType
+	StopPrice               Decimal
+	Quantity                Decimal // zero with ClosePosition
+	PositionSide            PositionSideType
+	TimeInForce             TimeInForceType
+	GoodTillDate            int64 // milliseconds, with TimeInForceTypeGTD
+	SelfTradePreventionMode SelfTradePreventionMode
+	ReduceOnly              bool
+	ClosePosition           bool
+	WorkingType             WorkingType
+	PriceProtect            bool
+	MarkPrice               Decimal // for the PERCENT_PRICE and MIN_NOTIONAL checks, skipped when zero
+}
+
+// TrailingStopMarketOrder follows the price at CallbackRate percent (0.1 to
+// 10) once ActivationPrice is reached, or right away without ActivationPrice
+type TrailingStopMarketOrder struct {
+	Symbol                  string
+	Side                    SideType
+	Quantity                Decimal
+	ActivationPrice         Decimal
+	CallbackRate            Decimal
+	PositionSide            PositionSideType
+	TimeInForce             TimeInForceType
+	GoodTillDate            int64 // milliseconds, with TimeInForceTypeGTD
+	SelfTradePreventionMode SelfTradePreventionMode
+	ReduceOnly              bool
+	ClosePosition           bool
+	WorkingType             WorkingType
+	PriceProtect            bool
+	MarkPrice               Decimal // for the PERCENT_PRICE and MIN_NOTIONAL checks, skipped when zero
+}
+
+// ModifyOrderRequest changes the price and quantity of an open limit order,
+// identified by OrderId or OrigClientOrderId. Price is ignored when
+// PriceMatch is set, the order then follows the order book
+type ModifyOrderRequest struct {
+	Symbol            string
+	OrderId           int64
+	OrigClientOrderId string
+	Side              SideType
+	Quantity          Decimal
+	Price             Decimal
+	PriceMatch        PriceMatchType
+}
+
+// OrderAmendment is a single modification of an order, with the price and
+// quantity before and after
+type OrderAmendment struct {
+	AmendmentId    int64
+	Symbol         string
+	Pair           string
+	OrderId        int64
+	ClientOrderId  string
+	PriceBefore    Decimal
+	PriceAfter     Decimal
+	QuantityBefore Decimal
+	QuantityAfter  Decimal
+	Count          int64 // number of modifications of the order so far
+	Time           int64
 }
 
 type OrderResponse struct {
 	ClientOrderId    string
-	CumQuantity      float64
-	CumQuote         float64
-	ExecutedQuantity float64
-	OrderId          int
-	AveragePrice     float64
-	OriginalQuantity float64
-	Price            float64
+	CumQuantity      Decimal
+	CumQuote         Decimal
+	ExecutedQuantity Decimal
+	OrderId          int64
+	AveragePrice     Decimal
+	OriginalQuantity Decimal
+	Price            Decimal
 	ReduceOnly       bool
-	Side             string
-	PositionSide     string
-	Status           string
-	StopPrice        float64
+	Side             SideType
+	PositionSide     PositionSideType
+	Status           OrderStatusType
+	StopPrice        Decimal
 	ClosePosition    bool
 	Symbol           string
-	TimeInForce      string
-	Type             string
-	OriginalType     string
-	ActivationPrice  float64
-	PriceRate        float64
+	TimeInForce      TimeInForceType
+	Type             OrderType
+	OriginalType     OrderType
+	ActivationPrice  Decimal
+	PriceRate        Decimal
+	Time             int64 // creation time, only set by order queries
 	UpdateTime       int64
-	WorkingType      string
+	WorkingType      WorkingType
 	PriceProtect     bool
 }
 
+// MarginTypeResult is the outcome of SetMarginType, Changed is false when
+// the symbol already had the margin type
+type MarginTypeResult struct {
+	Symbol     string
+	MarginType MarginType
+	Changed    bool
+}
+
+type LeverageResult struct {
+	Symbol           string
+	Leverage         int
+	MaxNotionalValue Decimal // max position notional at this leverage
+}
+
+// LeverageBracket is a notional tier of a symbol, positions with a notional
+// between NotionalFloor and NotionalCap can use up to InitialLeverage
+type LeverageBracket struct {
+	Bracket          int
+	InitialLeverage  int
+	NotionalFloor    Decimal
+	NotionalCap      Decimal
+	MaintMarginRatio Decimal
+	Cum              Decimal // maintenance amount, maintenance margin is notional * MaintMarginRatio - Cum
+}
+
+type SymbolLeverageBrackets struct {
+	Symbol       string
+	NotionalCoef Decimal // user bracket multiplier, 1 unless adjusted by binance
+	Brackets     []LeverageBracket
+}
+
+// LeveragePlan is the leverage bracket that applies to a notional
+type LeveragePlan struct {
+	Symbol           string
+	Notional         Decimal
+	MaxLeverage      int
+	MaintMarginRatio Decimal
+	MaintAmount      Decimal
+	MaintMargin      Decimal // notional * MaintMarginRatio - MaintAmount
+	Bracket          int
+	NotionalCap      Decimal
+}
+
+// PositionMarginChange is an addition or reduction of the margin of an isolated position
+type PositionMarginChange struct {
+	Symbol       string
+	PositionSide PositionSideType
+	Type         PositionMarginType
+	DeltaType    string // TRADE, USER_ADJUST ...
+	Amount       Decimal
+	Asset        string
+	Time         int64
+}
+
+// PositionKey identifies a position, in hedge mode a symbol has a LONG and a
+// SHORT position, in one-way mode a single BOTH position
+type PositionKey struct {
+	Symbol       string
+	PositionSide PositionSideType
+}
+
+func newPositionKey(symbol string, positionSide PositionSideType) PositionKey {
+	if positionSide == "" {
+		positionSide = PositionSideTypeBoth
+	}
+	return PositionKey{Symbol: symbol, PositionSide: positionSide}
+}
+
+// Account is a snapshot of the futures account, totals are in USDT
+type Account struct {
+	FeeTier                     int64
+	CanTrade                    bool
+	CanDeposit                  bool
+	CanWithdraw                 bool
+	MultiAssetsMargin           bool
+	TotalInitialMargin          Decimal
+	TotalMaintMargin            Decimal
+	TotalWalletBalance          Decimal
+	TotalUnrealizedProfit       Decimal
+	TotalMarginBalance          Decimal
+	TotalPositionInitialMargin  Decimal
+	TotalOpenOrderInitialMargin Decimal
+	TotalCrossWalletBalance     Decimal
+	TotalCrossUnPnl             Decimal
+	AvailableBalance            Decimal
+	MaxWithdrawAmount           Decimal
+	Assets                      []*AccountAsset
+	Positions                   []*AccountPosition
+	UpdateTime                  int64
+}
+
+type AccountAsset struct {
+	Asset                  string
+	WalletBalance          Decimal
+	UnrealizedProfit       Decimal
+	MarginBalance          Decimal
+	MaintMargin            Decimal
+	InitialMargin          Decimal
+	PositionInitialMargin  Decimal
+	OpenOrderInitialMargin Decimal
+	CrossWalletBalance     Decimal
+	CrossUnPnl             Decimal
+	AvailableBalance       Decimal
+	MaxWithdrawAmount      Decimal
+	MarginAvailable        bool
+	UpdateTime             int64
+}
+
+// AccountPosition is a position as listed in the account snapshot, every
+// symbol is listed even without an open position
+type AccountPosition struct {
+	Symbol                 string
+	PositionSide           PositionSideType
+	PositionAmount         Decimal // negative for short positions in one-way mode
+	EntryPrice             Decimal
+	BreakEvenPrice         Decimal
+	UnrealizedProfit       Decimal
+	InitialMargin          Decimal
+	MaintMargin            Decimal
+	PositionInitialMargin  Decimal
+	OpenOrderInitialMargin Decimal
+	Leverage               int64
+	Isolated               bool
+	MaxNotional            Decimal
+	BidNotional            Decimal
+	AskNotional            Decimal
+	UpdateTime             int64
+}
+
+func (p *AccountPosition) Key() PositionKey {
+	return newPositionKey(p.Symbol, p.PositionSide)
+}
+
+// Position is the position risk of a symbol
+type Position struct {
+	Symbol           string
+	PositionSide     PositionSideType
+	PositionAmount   Decimal // negative for short positions in one-way mode
+	EntryPrice       Decimal
+	BreakEvenPrice   Decimal
+	MarkPrice        Decimal
+	LiquidationPrice Decimal
+	UnrealizedProfit Decimal
+	Leverage         int64
+	MaxNotionalValue Decimal
+	MarginType       MarginType
+	IsolatedMargin   Decimal
+	IsolatedWallet   Decimal
+	IsAutoAddMargin  bool
+	Notional         Decimal
+	UpdateTime       int64
+}
+
+func (p *Position) Key() PositionKey {
+	return newPositionKey(p.Symbol, p.PositionSide)
+}
+
+// AccountTrade is a single fill of an order
+type AccountTrade struct {
+	Id              int64
+	OrderId         int64
+	Symbol          string
+	Side            SideType
+	PositionSide    PositionSideType
+	Buyer           bool
+	Maker           bool
+	Price           Decimal
+	Quantity        Decimal
+	QuoteQuantity   Decimal
+	RealizedPnl     Decimal
+	Commission      Decimal
+	CommissionAsset string
+	Time            int64
+}
+
+// Income is a single entry of the income history (realized pnl, funding fee, commission, transfer ...)
+type Income struct {
+	Symbol     string // empty for transfers
+	IncomeType IncomeType
+	Income     Decimal
+	Asset      string
+	Info       string
+	TranId     int64
+	TradeId    string // empty unless the income comes from a trade
+	Time       int64
+}
+
 type AccountEvent interface {
 	eventType() string
 }
 
 type MarginPosition struct {
 	Symbol            string
-	PositionSide      string
-	PositionAmount    float64
+	PositionSide      PositionSideType
+	PositionAmount    Decimal
 	MarginType        string
-	IsolatedWallet    float64
-	MarketPrice       float64
-	UnrealizedPnL     float64
-	MaintenanceMargin float64
+	IsolatedWallet    Decimal
+	MarketPrice       Decimal
+	UnrealizedPnL     Decimal
+	MaintenanceMargin Decimal
+}
+
+func (p *MarginPosition) Key() PositionKey {
+	return newPositionKey(p.Symbol, p.PositionSide)
 }
 
 type MarginCallEvent struct {
 	Event              string
 	EventTime          int64
-	CrossWalletBalance float64
+	CrossWalletBalance Decimal
 	Positions          []MarginPosition
 }
 
@@ -167,20 +475,24 @@ func (s MarginCallEvent) eventType() string {
 
 type AccountUpdateBalance struct {
 	Asset              string
-	WalletBalance      float64
-	CrossWalletBalance float64
-	BalanceChange      float64
+	WalletBalance      Decimal
+	CrossWalletBalance Decimal
+	BalanceChange      Decimal
 }
 
 type AccountUpdatePosition struct {
 	Symbol         string
-	PositionAmount float64
-	EntryPrice     float64
-	Accumulated    float64
-	UnrealizedPnL  float64
+	PositionAmount Decimal
+	EntryPrice     Decimal
+	Accumulated    Decimal
+	UnrealizedPnL  Decimal
 	MarginType     string
-	IsolatedWallet float64
-	PositionSide   string
+	IsolatedWallet Decimal
+	PositionSide   PositionSideType
+}
+
+func (p *AccountUpdatePosition) Key() PositionKey {
+	return newPositionKey(p.Symbol, p.PositionSide)
 }
 
 type AccountUpdateData struct {
@@ -202,31 +514,31 @@ type OrderTradeData struct {
 	OrderSide            string
 	OrderType            string
 	TimeInForce          string
-	Quantity             float64
-	Price                float64
-	AveragePrice         float64
-	StopPrice            float64
+	Quantity             Decimal
+	Price                Decimal
+	AveragePrice         Decimal
+	StopPrice            Decimal
 	ExectutionType       string
 	OrderStatus          string
 	OrderId              int64
-	LastFilledQuantity   float64
-	AccumulatedQuantity  float64
-	LastFilledPrice      float64
+	LastFilledQuantity   Decimal
+	AccumulatedQuantity  Decimal
+	LastFilledPrice      Decimal
 	CommissionAsset      string
-	Commission           float64
+	Commission           Decimal
 	TradeTime            int64
 	TradeId              int64
-	BidsNotional         float64
-	AskNotional          float64
+	BidsNotional         Decimal
+	AskNotional          Decimal
 	IsMakerSide          bool
 	IsReduceOnly         bool
 	StopPriceWorkingType string
 	OringalOrderType     string
-	PositionSide         string
+	PositionSide         PositionSideType
 	IsCloseAll           bool
-	ActivationPrice      float64
-	CallbackRate         float64
-	RealizedProfit       float64
+	ActivationPrice      Decimal
+	CallbackRate         Decimal
+	RealizedProfit       Decimal
 }
 
 type OrderTradeUpdateEvent struct {
@@ -0,0 +1,242 @@
+package binance
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+type PriceFilter struct {
+	MinPrice Decimal
+	MaxPrice Decimal
+	TickSize Decimal
+}
+
+type LotSizeFilter struct {
+	MinQuantity Decimal
+	MaxQuantity Decimal
+	StepSize    Decimal
+}
+
+// PercentPriceFilter bounds order prices to a band around the mark price
+type PercentPriceFilter struct {
+	MultiplierUp      Decimal
+	MultiplierDown    Decimal
+	MultiplierDecimal int64
+}
+
+type MinNotionalFilter struct {
+	Notional Decimal
+}
+
+type MaxNumOrdersFilter struct {
+	Limit int64
+}
+
+type jsonSymbolFilter struct {
+	FilterType        SymbolFilterType `json:"filterType"`
+	MinPrice          Decimal          `json:"minPrice"`
+	MaxPrice          Decimal          `json:"maxPrice"`
+	TickSize          Decimal          `json:"tickSize"`
+	MinQuantity       Decimal          `json:"minQty"`
+	MaxQuantity       Decimal          `json:"maxQty"`
+	StepSize          Decimal          `json:"stepSize"`
+	MultiplierUp      Decimal          `json:"multiplierUp"`
+	MultiplierDown    Decimal          `json:"multiplierDown"`
+	MultiplierDecimal Decimal          `json:"multiplierDecimal"`
+	Notional          Decimal          `json:"notional"`
+	Limit             int64            `json:"limit"`
+}
+
+// UnmarshalJSON decodes the symbol and its filters array into the typed filter fields
+func (info *InfoSymbol) UnmarshalJSON(data []byte) error {
+	type infoSymbol InfoSymbol
+	var obj struct {
+		*infoSymbol
+		Filters []jsonSymbolFilter `json:"filters"`
+	}
+	obj.infoSymbol = (*infoSymbol)(info)
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	for _, f := range obj.Filters {
+		switch f.FilterType {
+		case SymbolFilterTypePrice:
+			info.PriceFilter = &PriceFilter{
+				MinPrice: f.MinPrice,
+				MaxPrice: f.MaxPrice,
+				TickSize: f.TickSize,
+			}
+		case SymbolFilterTypeLotSize:
+			info.LotSize = &LotSizeFilter{
+				MinQuantity: f.MinQuantity,
+				MaxQuantity: f.MaxQuantity,
+				StepSize:    f.StepSize,
+			}
+		case SymbolFilterTypeMarketLotSize:
+			info.MarketLotSize = &LotSizeFilter{
+				MinQuantity: f.MinQuantity,
+				MaxQuantity: f.MaxQuantity,
+				StepSize:    f.StepSize,
+			}
+		case SymbolFilterTypePercentPrice:
+			info.PercentPrice = &PercentPriceFilter{
+				MultiplierUp:      f.MultiplierUp,
+				MultiplierDown:    f.MultiplierDown,
+				MultiplierDecimal: f.MultiplierDecimal.Truncate(0).bigInt().Int64(),
+			}
+		case SymbolFilterTypeMinNotional:
+			info.MinNotional = &MinNotionalFilter{
+				Notional: f.Notional,
+			}
+		case SymbolFilterTypeMaxNumOrders:
+			info.MaxNumOrders = &MaxNumOrdersFilter{
+				Limit: f.Limit,
+			}
+		case SymbolFilterTypeMaxNumAlgoOrders:
+			info.MaxNumAlgoOrders = &MaxNumOrdersFilter{
+				Limit: f.Limit,
+			}
+		}
+	}
+	return nil
+}
+
+// ErrInvalidOrder is matched by every OrderValidationError
+var ErrInvalidOrder = errors.New("invalid order")
+
+// OrderValidationError is returned when an order fails a symbol filter locally
+type OrderValidationError struct {
+	Symbol string
+	Field  string
+	Reason string
+}
+
+func (e *OrderValidationError) Error() string {
+	return fmt.Sprintf("invalid order for %s, %s : %s", e.Symbol, e.Field, e.Reason)
+}
+
+func (e *OrderValidationError) Is(target error) bool {
+	return target == ErrInvalidOrder
+}
+
+// OrderParams are the numeric parameters of an order checked against the symbol filters
+type OrderParams struct {
+	Type       OrderType
+	Side       SideType
+	Price      Decimal // zero for market orders
+	StopPrice  Decimal // zero for orders without a trigger
+	Quantity   Decimal // zero for closePosition orders
+	ReduceOnly bool
+
+	// mark price used for the PERCENT_PRICE band and the notional of market
+	// orders, those checks are skipped when it is zero
+	MarkPrice Decimal
+}
+
+func isMarketType(orderType OrderType) bool {
+	switch orderType {
+	case OrderTypeMarket, OrderTypeStopMarket, OrderTypeTakeProfitMarket, OrderTypeTrailingStopMarket:
+		return true
+	}
+	return false
+}
+
+// NormalizeOrder rounds the prices to the tick size and the quantity down to
+// the step size, then checks the price, lot size, min notional and percent
+// price filters. symbols without filters are rounded to their precision.
+func (info *InfoSymbol) NormalizeOrder(p OrderParams) (OrderParams, error) {
+	invalid := func(field, format string, args ...interface{}) (OrderParams, error) {
+		return p, &OrderValidationError{Symbol: info.Symbol, Field: field, Reason: fmt.Sprintf(format, args...)}
+	}
+
+	if p.Price.IsNegative() || p.StopPrice.IsNegative() || p.Quantity.IsNegative() {
+		return invalid("price", "negative price or quantity")
+	}
+	p.Price = info.roundPrice(p.Price)
+	p.StopPrice = info.roundPrice(p.StopPrice)
+
+	lotSize := info.LotSize
+	if isMarketType(p.Type) && info.MarketLotSize != nil {
+		lotSize = info.MarketLotSize
+	}
+	quantity := p.Quantity
+	if lotSize != nil && lotSize.StepSize.IsPositive() {
+		p.Quantity = p.Quantity.TruncateToStep(lotSize.StepSize)
+	} else {
+		p.Quantity = p.Quantity.Truncate(int32(info.QuantityPrecision))
+	}
+	if quantity.IsPositive() && p.Quantity.IsZero() {
+		return invalid("quantity", "%s is below the quantity step", quantity)
+	}
+
+	// PRICE_FILTER
+	if f := info.PriceFilter; f != nil {
+		for _, v := range []struct {
+			field string
+			price Decimal
+		}{{"price", p.Price}, {"stopPrice", p.StopPrice}} {
+			if v.price.IsZero() {
+				continue
+			}
+			if f.MinPrice.IsPositive() && v.price.LessThan(f.MinPrice) {
+				return invalid(v.field, "%s is below min price %s", v.price, f.MinPrice)
+			}
+			if f.MaxPrice.IsPositive() && v.price.GreaterThan(f.MaxPrice) {
+				return invalid(v.field, "%s is above max price %s", v.price, f.MaxPrice)
+			}
+		}
+	}
+
+	// LOT_SIZE / MARKET_LOT_SIZE
+	if lotSize != nil && !p.Quantity.IsZero() {
+		if lotSize.MinQuantity.IsPositive() && p.Quantity.LessThan(lotSize.MinQuantity) {
+			return invalid("quantity", "%s is below min quantity %s", p.Quantity, lotSize.MinQuantity)
+		}
+		if lotSize.MaxQuantity.IsPositive() && p.Quantity.GreaterThan(lotSize.MaxQuantity) {
+			return invalid("quantity", "%s is above max quantity %s", p.Quantity, lotSize.MaxQuantity)
+		}
+	}
+
+	// MIN_NOTIONAL, reduce only orders are exempt
+	price := p.Price
+	if price.IsZero() {
+		price = p.StopPrice
+	}
+	if price.IsZero() {
+		price = p.MarkPrice
+	}
+	if f := info.MinNotional; f != nil && !p.ReduceOnly && !p.Quantity.IsZero() && !price.IsZero() {
+		notional := price.Mul(p.Quantity)
+		if notional.LessThan(f.Notional) {
+			return invalid("quantity", "notional %s is below min notional %s", notional, f.Notional)
+		}
+	}
+
+	// PERCENT_PRICE, buy orders are capped above the mark price and sell orders below it
+	if f := info.PercentPrice; f != nil && p.MarkPrice.IsPositive() && !p.Price.IsZero() {
+		switch p.Side {
+		case SideTypeBuy:
+			upper := p.MarkPrice.Mul(f.MultiplierUp)
+			if f.MultiplierUp.IsPositive() && p.Price.GreaterThan(upper) {
+				return invalid("price", "%s is above %s (%s x mark price %s)", p.Price, upper, f.MultiplierUp, p.MarkPrice)
+			}
+		case SideTypeSell:
+			lower := p.MarkPrice.Mul(f.MultiplierDown)
+			if p.Price.LessThan(lower) {
+				return invalid("price", "%s is below %s (%s x mark price %s)", p.Price, lower, f.MultiplierDown, p.MarkPrice)
+			}
+		}
+	}
+
+	return p, nil
+}
+
+// roundPrice rounds price to the tick size, or the price precision without a price filter
+func (info *InfoSymbol) roundPrice(price Decimal) Decimal {
+	if info.PriceFilter != nil && info.PriceFilter.TickSize.IsPositive() {
+		return price.RoundToStep(info.PriceFilter.TickSize)
+	}
+	return price.Round(int32(info.PricePrecision))
+}
@@ -0,0 +1,56 @@
+package binance
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizeOrderPercentPrice(t *testing.T) {
+	info := &InfoSymbol{
+		Symbol:            "BTCUSDT",
+		PricePrecision:    2,
+		QuantityPrecision: 3,
+		PercentPrice: &PercentPriceFilter{
+			MultiplierUp:   MustParseDecimal("1.05"),
+			MultiplierDown: MustParseDecimal("0.95"),
+		},
+	}
+	mark := MustParseDecimal("100")
+
+	tests := []struct {
+		name    string
+		side    SideType
+		price   string
+		wantErr bool
+	}{
+		{"buy inside the band", SideTypeBuy, "104", false},
+		{"buy at the upper bound", SideTypeBuy, "105", false},
+		{"buy above the upper bound", SideTypeBuy, "105.01", true},
+		{"buy below the lower bound", SideTypeBuy, "50", false},
+		{"sell inside the band", SideTypeSell, "96", false},
+		{"sell at the lower bound", SideTypeSell, "95", false},
+		{"sell below the lower bound", SideTypeSell, "94.99", true},
+		{"sell above the upper bound", SideTypeSell, "150", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := info.NormalizeOrder(OrderParams{
+				Type:      OrderTypeLimit,
+				Side:      tt.side,
+				Price:     MustParseDecimal(tt.price),
+				Quantity:  MustParseDecimal("1"),
+				MarkPrice: mark,
+			})
+			if tt.wantErr {
+				var verr *OrderValidationError
+				if !errors.Is(err, ErrInvalidOrder) || !errors.As(err, &verr) || verr.Field != "price" {
+					t.Fatalf("err = %v, want a price validation error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,295 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// symbolMissRefreshInterval limits the refreshes triggered by unknown symbols
+const symbolMissRefreshInterval = 30 * time.Second
+
+type SymbolChangeType string
+
+const (
+	SymbolChangeTypeListed        SymbolChangeType = "LISTED"
+	SymbolChangeTypeDelisted      SymbolChangeType = "DELISTED"
+	SymbolChangeTypeStatusChanged SymbolChangeType = "STATUS_CHANGED"
+)
+
+// SymbolChange is sent to subscribers when a refresh finds a new, removed or changed symbol
+type SymbolChange struct {
+	Type      SymbolChangeType
+	Symbol    string
+	OldStatus string
+	NewStatus string
+	Info      *InfoSymbol // nil for delisted symbols
+}
+
+// SymbolQuery filters symbols, empty fields match everything
+type SymbolQuery struct {
+	QuoteAsset   string
+	Status       SymbolStatusType
+	ContractType ContractType
+}
+
+func (q SymbolQuery) matches(info *InfoSymbol) bool {
+	return (q.QuoteAsset == "" || q.QuoteAsset == info.QuoteAsset) &&
+		(q.Status == "" || string(q.Status) == info.Status) &&
+		(q.ContractType == "" || string(q.ContractType) == info.ContractType)
+}
+
+// SymbolRegistry caches exchangeInfo and looks up symbols by name. it is
+// loaded on first use, refreshed on an interval with StartAutoRefresh,
+// whenever binance rejects a request with -1121 (invalid symbol) and when a
+// lookup misses, at most once every symbolMissRefreshInterval
+type SymbolRegistry struct {
+	c             *Client
+	mu            sync.RWMutex
+	info          *ExchangeInfo
+	symbols       map[string]*InfoSymbol
+	updatedAt     time.Time
+	subs          []chan SymbolChange
+	flight        *registryRefresh // the shared refresh in progress, if any
+	missRefreshAt time.Time
+}
+
+// registryRefresh is a refresh shared by every caller arriving while it runs
+type registryRefresh struct {
+	done chan struct{}
+	err  error
+}
+
+func newSymbolRegistry(c *Client) *SymbolRegistry {
+	return &SymbolRegistry{c: c}
+}
+
+// Symbols returns the symbol registry of the client
+func (c *Client) Symbols() *SymbolRegistry {
+	return c.registry
+}
+
+func (r *SymbolRegistry) Refresh() error {
+	return r.RefreshContext(context.Background())
+}
+
+// RefreshContext reloads exchangeInfo and notifies subscribers of changed symbols
+func (r *SymbolRegistry) RefreshContext(ctx context.Context) error {
+	info, err := r.c.GetExchangeInfoContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	symbols := make(map[string]*InfoSymbol, len(info.Symbols))
+	for i := range info.Symbols {
+		symbols[info.Symbols[i].Symbol] = &info.Symbols[i]
+	}
+
+	r.mu.Lock()
+	old := r.symbols
+	r.info = info
+	r.symbols = symbols
+	r.updatedAt = time.Now()
+	subs := r.subs
+	r.mu.Unlock()
+
+	if old == nil {
+		return nil
+	}
+	for _, change := range diffSymbols(old, symbols) {
+		for _, ch := range subs {
+			select {
+			case ch <- change:
+			default:
+				r.c.logger.Warn("error in symbol registry : subscriber channel is full", "symbol", change.Symbol)
+			}
+		}
+	}
+	return nil
+}
+
+func diffSymbols(old, current map[string]*InfoSymbol) []SymbolChange {
+	changes := make([]SymbolChange, 0)
+	for name, info := range current {
+		prev, ok := old[name]
+		if !ok {
+			changes = append(changes, SymbolChange{Type: SymbolChangeTypeListed, Symbol: name, NewStatus: info.Status, Info: info})
+		} else if prev.Status != info.Status {
+			changes = append(changes, SymbolChange{Type: SymbolChangeTypeStatusChanged, Symbol: name, OldStatus: prev.Status, NewStatus: info.Status, Info: info})
+		}
+	}
+	for name, prev := range old {
+		if _, ok := current[name]; !ok {
+			changes = append(changes, SymbolChange{Type: SymbolChangeTypeDelisted, Symbol: name, OldStatus: prev.Status})
+		}
+	}
+	return changes
+}
+
+// startRefresh joins the refresh in progress or starts a new one. the refresh
+// is not tied to the context of a caller, so one caller giving up does not
+// fail the others
+func (r *SymbolRegistry) startRefresh() (call *registryRefresh, started bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.flight != nil {
+		return r.flight, false
+	}
+	call = &registryRefresh{done: make(chan struct{})}
+	r.flight = call
+	go func() {
+		call.err = r.RefreshContext(context.Background())
+		r.mu.Lock()
+		r.flight = nil
+		r.mu.Unlock()
+		close(call.done)
+	}()
+	return call, true
+}
+
+// refreshShared waits for a shared refresh or until ctx is done
+func (r *SymbolRegistry) refreshShared(ctx context.Context) error {
+	call, _ := r.startRefresh()
+	select {
+	case <-call.done:
+		return call.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// refreshAsync refreshes a loaded registry in the background, at most once at a time
+func (r *SymbolRegistry) refreshAsync() {
+	if !r.loaded() {
+		return
+	}
+	call, started := r.startRefresh()
+	if !started {
+		return
+	}
+	go func() {
+		<-call.done
+		if call.err != nil {
+			r.c.logger.Error("error in refreshing symbols", "err", call.err)
+		}
+	}()
+}
+
+// allowMissRefresh reports whether a lookup miss may refresh the registry
+func (r *SymbolRegistry) allowMissRefresh() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	if now.Sub(r.missRefreshAt) < symbolMissRefreshInterval {
+		return false
+	}
+	r.missRefreshAt = now
+	return true
+}
+
+func (r *SymbolRegistry) loaded() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.symbols != nil
+}
+
+func (r *SymbolRegistry) load(ctx context.Context) error {
+	if r.loaded() {
+		return nil
+	}
+	return r.refreshShared(ctx)
+}
+
+func (r *SymbolRegistry) lookup(symbol string) (*InfoSymbol, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	info, ok := r.symbols[strings.ToUpper(symbol)]
+	return info, ok
+}
+
+func (r *SymbolRegistry) Symbol(symbol string) (*InfoSymbol, error) {
+	return r.SymbolContext(context.Background(), symbol)
+}
+
+// SymbolContext returns the exchange info of a symbol, loading the registry on
+// first use. an unknown symbol refreshes the registry once before failing, in
+// case it was listed after the last refresh
+func (r *SymbolRegistry) SymbolContext(ctx context.Context, symbol string) (*InfoSymbol, error) {
+	if err := r.load(ctx); err != nil {
+		return nil, err
+	}
+	info, ok := r.lookup(symbol)
+	if !ok && r.allowMissRefresh() {
+		if err := r.refreshShared(ctx); err != nil {
+			return nil, err
+		}
+		info, ok = r.lookup(symbol)
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown symbol %s", symbol)
+	}
+	return info, nil
+}
+
+func (r *SymbolRegistry) Filter(q SymbolQuery) ([]*InfoSymbol, error) {
+	return r.FilterContext(context.Background(), q)
+}
+
+// FilterContext returns the symbols matching q, loading the registry on first use
+func (r *SymbolRegistry) FilterContext(ctx context.Context, q SymbolQuery) ([]*InfoSymbol, error) {
+	if err := r.load(ctx); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	symbols := make([]*InfoSymbol, 0)
+	for i := range r.info.Symbols {
+		if info := &r.info.Symbols[i]; q.matches(info) {
+			symbols = append(symbols, info)
+		}
+	}
+	return symbols, nil
+}
+
+// ExchangeInfo returns the cached exchange info, nil before the first load
+func (r *SymbolRegistry) ExchangeInfo() *ExchangeInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.info
+}
+
+// UpdatedAt returns the time of the last successful refresh
+func (r *SymbolRegistry) UpdatedAt() time.Time {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.updatedAt
+}
+
+// Subscribe returns a channel receiving symbol changes found by later refreshes
+func (r *SymbolRegistry) Subscribe() <-chan SymbolChange {
+	ch := make(chan SymbolChange, 100)
+	r.mu.Lock()
+	r.subs = append(r.subs, ch)
+	r.mu.Unlock()
+	return ch
+}
+
+// StartAutoRefresh refreshes the registry every interval until ctx is cancelled
+func (r *SymbolRegistry) StartAutoRefresh(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := r.RefreshContext(ctx); err != nil && ctx.Err() == nil {
+					r.c.logger.Error("error in refreshing symbols", "err", err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,90 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newRegistryServer serves exchangeInfo with the symbols of the current listing
+func newRegistryServer(t *testing.T, listing *atomic.Value, calls *int32) *Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		time.Sleep(20 * time.Millisecond) // keep concurrent callers waiting on the same load
+		symbols := ""
+		for i, s := range listing.Load().([]string) {
+			if i > 0 {
+				symbols += ","
+			}
+			symbols += fmt.Sprintf(`{"symbol":%q,"status":"TRADING"}`, s)
+		}
+		fmt.Fprintf(w, `{"symbols":[%s]}`, symbols)
+	}))
+	t.Cleanup(srv.Close)
+	return NewClient("k", "s", WithBaseURL(srv.URL))
+}
+
+func TestSymbolRegistrySharedLoad(t *testing.T) {
+	var calls int32
+	var listing atomic.Value
+	listing.Store([]string{"BTCUSDT"})
+	r := newRegistryServer(t, &listing, &calls).Symbols()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := r.Symbol("BTCUSDT"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("exchangeInfo fetched %d times, want 1", n)
+	}
+}
+
+func TestSymbolRegistryRefreshOnMiss(t *testing.T) {
+	var calls int32
+	var listing atomic.Value
+	listing.Store([]string{"BTCUSDT"})
+	r := newRegistryServer(t, &listing, &calls).Symbols()
+
+	if _, err := r.Symbol("BTCUSDT"); err != nil {
+		t.Fatal(err)
+	}
+	listing.Store([]string{"BTCUSDT", "NEWUSDT"})
+
+	// concurrent misses refresh once
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.SymbolContext(context.Background(), "NEWUSDT")
+		}()
+	}
+	wg.Wait()
+	if info, err := r.Symbol("newusdt"); err != nil || info.Symbol != "NEWUSDT" {
+		t.Fatalf("Symbol(newusdt) = %v, %v after a refresh", info, err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Errorf("exchangeInfo fetched %d times, want 2", n)
+	}
+
+	// further misses are rate limited
+	if _, err := r.Symbol("NOSUCHUSDT"); err == nil {
+		t.Error("unknown symbol found")
+	}
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Errorf("exchangeInfo fetched %d times after a rate limited miss, want 2", n)
+	}
+}
@@ -1,8 +1,8 @@
 package binance
 
 import (
+	"context"
 	"encoding/json"
-	"log"
 	"net/http"
 )
 
@@ -16,22 +16,26 @@ func (c *Client) NewTickerService() *TickerService {
 	}
 }
 
-func (m *TickerService) GetPriceTickers() []PriceTicker {
+func (m *TickerService) GetPriceTickers() ([]PriceTicker, error) {
+	return m.GetPriceTickersContext(context.Background())
+}
+
+func (m *TickerService) GetPriceTickersContext(ctx context.Context) ([]PriceTicker, error) {
 	req := request{
 		method:   http.MethodGet,
 		endpoint: endPoint24hrTicker,
 		query:    nil,
 	}
-	data, err := m.c.callAPI(&req)
+	data, err := m.c.callAPI(ctx, &req)
 	if err != nil {
-		return []PriceTicker{}
+		return nil, err
 	}
 
 	var tickerList []jsonTicker24hr
 	err = json.Unmarshal(data, &tickerList)
 	if err != nil {
-		log.Println("error in parsing tickers response : ", err, string(data))
-		return []PriceTicker{}
+		m.c.logger.Error("error in parsing tickers response", "err", err)
+		return nil, err
 	}
 	// log.Println("#tickers : ", len(tickers))
 
@@ -39,16 +43,16 @@ func (m *TickerService) GetPriceTickers() []PriceTicker {
 	for _, t := range tickerList {
 		ticker := PriceTicker{
 			Symbol:             t.Symbol,
-			PriceChange:        parseFloat(t.PriceChange),
-			PriceChangePercent: parseFloat(t.PriceChangePercent),
-			WeightedAvgPrice:   parseFloat(t.WeightedAvgPrice),
-			LastPrice:          parseFloat(t.LastPrice),
-			LastQuantity:       parseFloat(t.LastQuantity),
-			OpenPrice:          parseFloat(t.OpenPrice),
-			HighPrice:          parseFloat(t.HighPrice),
-			LowPrice:           parseFloat(t.LowPrice),
-			BaseVolume:         parseFloat(t.Volume),
-			QuoteVolume:        parseFloat(t.QuoteVolume),
+			PriceChange:        parseDecimal(t.PriceChange),
+			PriceChangePercent: parseDecimal(t.PriceChangePercent),
+			WeightedAvgPrice:   parseDecimal(t.WeightedAvgPrice),
+			LastPrice:          parseDecimal(t.LastPrice),
+			LastQuantity:       parseDecimal(t.LastQuantity),
+			OpenPrice:          parseDecimal(t.OpenPrice),
+			HighPrice:          parseDecimal(t.HighPrice),
+			LowPrice:           parseDecimal(t.LowPrice),
+			BaseVolume:         parseDecimal(t.Volume),
+			QuoteVolume:        parseDecimal(t.QuoteVolume),
 			OpenTime:           t.OpenTime,
 			CloseTime:          t.CloseTime,
 			FirstTradeId:       t.FirstTradeId,
@@ -57,7 +61,7 @@ func (m *TickerService) GetPriceTickers() []PriceTicker {
 		}
 		tickers = append(tickers, ticker)
 	}
-	return tickers
+	return tickers, nil
 }
 
 type jsonTicker24hr struct {
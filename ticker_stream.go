@@ -1,9 +1,9 @@
 package binance
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 )
 
 type TickerStream struct {
@@ -34,72 +34,93 @@ type jsonPriceTickerEvent struct {
 }
 
 func (c *Client) NewTickerStream() *TickerStream {
-	url := fmt.Sprintf("%s/!ticker@arr", baseWsMainURL)
+	url := fmt.Sprintf("%s/!ticker@arr", c.wsBaseURL)
 	return &TickerStream{
 		c:   c,
 		out: make(chan *PriceTicker, 100),
-		wss: &WebSocketStream{
-			url:     url,
-			timeout: 2 * 60 * 60 * 1000,
-		},
+		wss: newWebSocketStream(url, c.logger),
 	}
 }
 
 func (s *TickerStream) Start() <-chan *PriceTicker {
-	s.wss.isActive = true
-	go s.startStream()
+	return s.StartContext(context.Background())
+}
+
+// StartContext starts the stream, the out channel is closed once ctx is cancelled
+func (s *TickerStream) StartContext(ctx context.Context) <-chan *PriceTicker {
+	ctx = s.wss.start(ctx)
+	go s.startStream(ctx)
 	return s.out
 }
 
 func (s *TickerStream) Stop() {
-	s.wss.isActive = false
+	s.wss.stop()
 }
 
-func (s *TickerStream) startStream() {
+// Events reports reconnects of the stream, it is closed with the out channel
+func (s *TickerStream) Events() <-chan StreamEvent {
+	return s.wss.events
+}
+
+func (s *TickerStream) startStream(ctx context.Context) {
 
 	defer close(s.out)
+	defer s.wss.finish()
 	messageCount := 0
 
 	for {
-		if !s.wss.isActive {
+		if !s.wss.isActive.Load() {
 			break
 		}
-		msg, err := s.wss.getNextMessage()
+		msg, err := s.wss.getNextMessage(ctx)
 		if err != nil {
 			break
 		}
 
-		var eventList []jsonPriceTickerEvent
-		err = json.Unmarshal(msg, &eventList)
+		tickers, err := parseTickerEvents(msg)
 		if err != nil {
-			log.Println("error in parsing ws ticker : ", err)
+			s.c.logger.Error("error in parsing ws ticker", "err", err)
 		}
 
-		for _, event := range eventList {
-			ticker := PriceTicker{
-				Symbol:             event.Symbol,
-				PriceChange:        parseFloat(event.PriceChange),
-				PriceChangePercent: parseFloat(event.PriceChangePercent),
-				WeightedAvgPrice:   parseFloat(event.WeightedAvgPrice),
-				LastPrice:          parseFloat(event.LastPrice),
-				LastQuantity:       parseFloat(event.LastQuantity),
-				OpenPrice:          parseFloat(event.OpenPrice),
-				HighPrice:          parseFloat(event.HighPrice),
-				LowPrice:           parseFloat(event.LowPrice),
-				BaseVolume:         parseFloat(event.BaseVolume),
-				QuoteVolume:        parseFloat(event.QuoteVolume),
-				OpenTime:           event.OpenTime,
-				CloseTime:          event.CloseTime,
-				FirstTradeId:       event.FirstTradeId,
-				LastTradeId:        event.LastTradeId,
-				TradeCount:         event.TradeCount,
-			}
+		for _, ticker := range tickers {
 			select {
-			case s.out <- &ticker:
+			case s.out <- ticker:
 				messageCount += 1
 			default:
-				log.Println("error in ticker stream : out channel is full")
+				s.c.logger.Warn("error in ticker stream : out channel is full", "symbol", ticker.Symbol)
 			}
 		}
 	}
 }
+
+// parseTickerEvents parses a !ticker@arr message
+func parseTickerEvents(data []byte) ([]*PriceTicker, error) {
+	var eventList []jsonPriceTickerEvent
+	err := json.Unmarshal(data, &eventList)
+	if err != nil {
+		return nil, err
+	}
+
+	tickers := make([]*PriceTicker, 0, len(eventList))
+	for _, event := range eventList {
+		tickers = append(tickers, &PriceTicker{
+			Symbol:             event.Symbol,
+			PriceChange:        parseDecimal(event.PriceChange),
+			PriceChangePercent: parseDecimal(event.PriceChangePercent),
+			WeightedAvgPrice:   parseDecimal(event.WeightedAvgPrice),
+			LastPrice:          parseDecimal(event.LastPrice),
+			LastQuantity:       parseDecimal(event.LastQuantity),
+			OpenPrice:          parseDecimal(event.OpenPrice),
+			HighPrice:          parseDecimal(event.HighPrice),
+			LowPrice:           parseDecimal(event.LowPrice),
+			BaseVolume:         parseDecimal(event.BaseVolume),
+			QuoteVolume:        parseDecimal(event.QuoteVolume),
+			OpenTime:           event.OpenTime,
+			CloseTime:          event.CloseTime,
+			FirstTradeId:       event.FirstTradeId,
+			LastTradeId:        event.LastTradeId,
+			TradeCount:         event.TradeCount,
+		})
+	}
+	return tickers, nil
+}
@@ -0,0 +1,108 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+const timeSyncSamples = 3
+
+// TimeSync is the result of a clock synchronisation against the server
+type TimeSync struct {
+	ServerTime int64         // server time in milliseconds
+	Offset     time.Duration // server clock minus local clock
+	RTT        time.Duration // round trip time of the sample used
+}
+
+type jsonServerTime struct {
+	ServerTime int64 `json:"serverTime"`
+}
+
+func (c *Client) GetServerTime() (int64, error) {
+	return c.GetServerTimeContext(context.Background())
+}
+
+// GetServerTimeContext returns the server time in milliseconds
+func (c *Client) GetServerTimeContext(ctx context.Context) (int64, error) {
+	req := request{
+		method:   http.MethodGet,
+		endpoint: endPointServerTime,
+	}
+	data, err := c.callAPI(ctx, &req)
+	if err != nil {
+		return 0, err
+	}
+	var res jsonServerTime
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		c.logger.Error("error in parsing server time", "err", err, "data", string(data))
+		return 0, err
+	}
+	return res.ServerTime, nil
+}
+
+func (c *Client) SyncTime() (*TimeSync, error) {
+	return c.SyncTimeContext(context.Background())
+}
+
+// SyncTimeContext measures the offset between the local and the server clock,
+// keeping the sample with the lowest round trip time, and applies it to every
+// signed request made afterwards
+func (c *Client) SyncTimeContext(ctx context.Context) (*TimeSync, error) {
+	var best *TimeSync
+	for i := 0; i < timeSyncSamples; i++ {
+		start := time.Now()
+		serverTime, err := c.GetServerTimeContext(ctx)
+		if err != nil {
+			if best != nil {
+				break
+			}
+			return nil, err
+		}
+		rtt := time.Since(start)
+		localTime := start.Add(rtt / 2).UnixMilli()
+		sample := &TimeSync{
+			ServerTime: serverTime,
+			Offset:     time.Duration(serverTime-localTime) * time.Millisecond,
+			RTT:        rtt,
+		}
+		if best == nil || sample.RTT < best.RTT {
+			best = sample
+		}
+	}
+
+	atomic.StoreInt64(&c.timeOffset, best.Offset.Milliseconds())
+	c.logger.Debug("synced server time", "offset", best.Offset, "rtt", best.RTT)
+	return best, nil
+}
+
+// StartTimeSync re-syncs the clock offset every interval until ctx is cancelled
+func (c *Client) StartTimeSync(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if _, err := c.SyncTimeContext(ctx); err != nil && ctx.Err() == nil {
+				c.logger.Error("error in syncing server time", "err", err)
+			}
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// TimeOffset returns the offset currently applied to signed requests
+func (c *Client) TimeOffset() time.Duration {
+	return time.Duration(atomic.LoadInt64(&c.timeOffset)) * time.Millisecond
+}
+
+// timestamp returns the current server time estimate in milliseconds
+func (c *Client) timestamp() int64 {
+	return CurrentTimestamp() + atomic.LoadInt64(&c.timeOffset)
+}
@@ -4,7 +4,6 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"fmt"
-	"log"
 	"strconv"
 	"time"
 )
@@ -15,31 +14,14 @@ func CurrentTimestamp() int64 {
 
 func computeSignature(message, secret string) string {
 	mac := hmac.New(sha256.New, []byte(secret))
-	_, err := mac.Write([]byte(message))
-	if err != nil {
-		log.Println("error in computing signature : ", err)
-	}
+	mac.Write([]byte(message))
 	return fmt.Sprintf("%x", mac.Sum(nil))
 }
 
-func parseFloat(str string) float64 {
-	if str == "" {
-		return 0
-	}
-	a, err := strconv.ParseFloat(str, 64)
-	if err != nil {
-		log.Println("error in parsing float : ", err)
-	}
-	return a
-}
-
 func ParseInt(str string) int64 {
 	if str == "" {
 		return 0
 	}
-	a, err := strconv.ParseInt(str, 10, 64)
-	if err != nil {
-		log.Println("error in parsing integer : ", err)
-	}
+	a, _ := strconv.ParseInt(str, 10, 64)
 	return a
 }
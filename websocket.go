@@ -1,43 +1,351 @@
 package binance
 
 import (
-	"fmt"
-	"log"
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/gorilla/websocket"
 )
 
+const (
+	wsPingInterval = time.Minute
+	wsReadTimeout  = 5 * time.Minute // without any message, ping or pong the connection is stale
+	wsWriteTimeout = 10 * time.Second
+	wsMaxLifetime  = 23 * time.Hour // binance closes connections after 24 hours
+	wsMinBackoff   = 500 * time.Millisecond
+	wsMaxBackoff   = 30 * time.Second
+	wsSendInterval = 110 * time.Millisecond // binance allows 10 incoming messages per second, pings and pongs included
+	wsEventsBuffer = 16
+)
+
+var (
+	errStreamInactive     = errors.New("stream is inactive")
+	errStreamNotConnected = errors.New("stream is not connected")
+)
+
+type StreamEventType string
+
+const (
+	StreamEventConnected    StreamEventType = "CONNECTED"
+	StreamEventDisconnected StreamEventType = "DISCONNECTED"
+	StreamEventReconnected  StreamEventType = "RECONNECTED"
+	StreamEventRotated      StreamEventType = "ROTATED" // planned reconnect before the 24h limit
+)
+
+// StreamEvent reports a change of the connection of a stream. messages sent
+// by binance between DISCONNECTED and RECONNECTED are lost, Gap is the
+// length of that window
+type StreamEvent struct {
+	Type    StreamEventType
+	Time    time.Time
+	Attempt int           // failed connection attempts before RECONNECTED
+	Gap     time.Duration // time without connection, for RECONNECTED
+	Err     error         // cause of DISCONNECTED
+	Streams []string      // streams of the connection, set by StreamHub
+}
+
+// WebSocketStream is the connection shared by every stream. it reconnects
+// with exponential backoff until the stream is stopped, answers server pings
+// and pings the server itself, treats a connection without traffic for
+// wsReadTimeout as dead and reconnects before binance's 24h disconnect
 type WebSocketStream struct {
-	isActive   bool
-	url        string
-	wsConn     *websocket.Conn
-	wsOpenTime int64
-	timeout    int64
+	isActive atomic.Bool
+	url      string
+	// urlFunc resolves the url on every connect when set, e.g. with a new listen key
+	urlFunc func(ctx context.Context) (string, error)
+	// onConnect runs after every connect, before the first message is read
+	onConnect func(ctx context.Context)
+	// onDisconnect runs when the connection drops, before reconnecting
+	onDisconnect func()
+	logger       Logger
+
+	pingInterval time.Duration
+	readTimeout  time.Duration
+	maxLifetime  time.Duration
+
+	mu             sync.Mutex
+	wsConn         *websocket.Conn
+	wsOpenTime     time.Time
+	connDone       chan struct{}
+	cancel         context.CancelFunc
+	events         chan StreamEvent
+	connected      bool // connected at least once
+	disconnectedAt time.Time
+
+	writeMu  sync.Mutex
+	lastSend time.Time
+}
+
+func newWebSocketStream(url string, logger Logger) *WebSocketStream {
+	return &WebSocketStream{
+		url:          url,
+		logger:       logger,
+		pingInterval: wsPingInterval,
+		readTimeout:  wsReadTimeout,
+		maxLifetime:  wsMaxLifetime,
+		events:       make(chan StreamEvent, wsEventsBuffer),
+	}
 }
 
-func (s *WebSocketStream) getNextMessage() ([]byte, error) {
-	if !s.isActive {
-		return nil, fmt.Errorf("channel is inactive")
+// start marks the stream active and returns a context which is
+// cancelled either by the parent or by stop
+func (s *WebSocketStream) start(parent context.Context) context.Context {
+	ctx, cancel := context.WithCancel(parent)
+	s.cancel = cancel
+	s.isActive.Store(true)
+	return ctx
+}
+
+func (s *WebSocketStream) stop() {
+	s.isActive.Store(false)
+	if s.cancel != nil {
+		s.cancel()
 	}
+}
 
-	if s.wsOpenTime == 0 || (CurrentTimestamp()-s.wsOpenTime) > s.timeout {
-		if s.wsConn != nil {
-			s.wsConn.Close()
+func (s *WebSocketStream) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.wsConn != nil {
+		s.wsConn.Close()
+		close(s.connDone)
+		s.wsConn = nil
+	}
+}
+
+// finish closes the connection and the events channel once the stream ends
+func (s *WebSocketStream) finish() {
+	s.close()
+	close(s.events)
+}
+
+// drop closes the current connection, the reader reconnects right away
+func (s *WebSocketStream) drop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.wsConn != nil {
+		s.wsConn.Close()
+	}
+}
+
+func (s *WebSocketStream) emit(event StreamEvent) {
+	event.Time = time.Now()
+	select {
+	case s.events <- event:
+	default:
+		s.logger.Warn("stream events channel is full, dropping event", "type", event.Type)
+	}
+}
+
+// getNextMessage returns the next message, reconnecting as needed. it only
+// fails once the stream is stopped or its context is cancelled
+func (s *WebSocketStream) getNextMessage(ctx context.Context) ([]byte, error) {
+	for {
+		if !s.isActive.Load() {
+			return nil, errStreamInactive
 		}
-		log.Println("opening wstream : " + s.url)
-		c, _, err := websocket.DefaultDialer.Dial(s.url, nil)
-		if err != nil {
-			log.Println("error in opening wstream : ", err, s.url)
+		if err := ctx.Err(); err != nil {
 			return nil, err
 		}
-		s.wsConn = c
-		s.wsOpenTime = CurrentTimestamp()
+
+		if s.wsConn == nil {
+			if err := s.connect(ctx); err != nil {
+				return nil, err
+			}
+		} else if time.Since(s.wsOpenTime) > s.maxLifetime {
+			if err := s.rotate(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		_, msg, err := s.wsConn.ReadMessage()
+		if err == nil {
+			s.wsConn.SetReadDeadline(time.Now().Add(s.readTimeout))
+			return msg, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		s.logger.Warn("wstream disconnected, reconnecting", "err", err, "url", s.url)
+		s.close()
+		s.disconnectedAt = time.Now()
+		if s.onDisconnect != nil {
+			s.onDisconnect()
+		}
+		s.emit(StreamEvent{Type: StreamEventDisconnected, Err: err})
 	}
+}
+
+// connect dials until it succeeds or ctx is cancelled
+func (s *WebSocketStream) connect(ctx context.Context) error {
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			timer := time.NewTimer(wsBackoff(attempt))
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			}
+		}
 
-	_, msg, err := s.wsConn.ReadMessage()
+		conn, err := s.dial(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			s.logger.Error("error in opening wstream", "err", err, "url", s.url, "attempt", attempt+1)
+			continue
+		}
+
+		s.setConn(ctx, conn)
+		if s.onConnect != nil {
+			s.onConnect(ctx)
+		}
+		if !s.connected {
+			s.connected = true
+			s.emit(StreamEvent{Type: StreamEventConnected})
+		} else {
+			s.emit(StreamEvent{Type: StreamEventReconnected, Attempt: attempt, Gap: time.Since(s.disconnectedAt)})
+		}
+		return nil
+	}
+}
+
+// rotate opens a new connection before closing the old one
+func (s *WebSocketStream) rotate(ctx context.Context) error {
+	s.logger.Info("rotating wstream", "url", s.url, "age", time.Since(s.wsOpenTime))
+	conn, err := s.dial(ctx)
 	if err != nil {
-		log.Println("error in reading ws message : ", err, s.url)
-		return nil, err
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		// keep reading the old connection, retried on the next message
+		s.logger.Error("error in rotating wstream", "err", err, "url", s.url)
+		s.wsOpenTime = s.wsOpenTime.Add(s.pingInterval)
+		return nil
+	}
+	s.close()
+	s.setConn(ctx, conn)
+	if s.onConnect != nil {
+		s.onConnect(ctx)
+	}
+	s.emit(StreamEvent{Type: StreamEventRotated})
+	return nil
+}
+
+func (s *WebSocketStream) dial(ctx context.Context) (*websocket.Conn, error) {
+	if s.urlFunc != nil {
+		url, err := s.urlFunc(ctx)
+		if err != nil {
+			return nil, err
+		}
+		s.url = url
+	}
+	s.logger.Info("opening wstream", "url", s.url)
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, s.url, nil)
+	return conn, err
+}
+
+func (s *WebSocketStream) setConn(ctx context.Context, conn *websocket.Conn) {
+	conn.SetReadDeadline(time.Now().Add(s.readTimeout))
+	conn.SetPingHandler(func(data string) error {
+		conn.SetReadDeadline(time.Now().Add(s.readTimeout))
+		err := s.write(ctx, conn, func(conn *websocket.Conn) error {
+			return conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(wsWriteTimeout))
+		})
+		if errors.Is(err, websocket.ErrCloseSent) {
+			return nil
+		}
+		return err
+	})
+	conn.SetPongHandler(func(string) error {
+		conn.SetReadDeadline(time.Now().Add(s.readTimeout))
+		return nil
+	})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.wsConn = conn
+	s.wsOpenTime = time.Now()
+	s.connDone = make(chan struct{})
+	go s.keepalive(ctx, conn, s.connDone)
+}
+
+// keepalive pings the server and unblocks a pending read once ctx is cancelled
+func (s *WebSocketStream) keepalive(ctx context.Context, conn *websocket.Conn, connDone <-chan struct{}) {
+	ticker := time.NewTicker(s.pingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+			return
+		case <-connDone:
+			return
+		case <-ticker.C:
+			err := s.write(ctx, conn, func(conn *websocket.Conn) error {
+				return conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout))
+			})
+			if err != nil && ctx.Err() == nil {
+				s.logger.Warn("error in sending ws ping", "err", err, "url", s.url)
+			}
+		}
+	}
+}
+
+// send writes a json frame on the current connection, spacing frames by
+// wsSendInterval to stay below the inbound message limit
+func (s *WebSocketStream) send(ctx context.Context, v interface{}) error {
+	return s.write(ctx, nil, func(conn *websocket.Conn) error {
+		conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+		return conn.WriteJSON(v)
+	})
+}
+
+// write runs fn on conn, or on the current connection when conn is nil, at
+// least wsSendInterval after the previous frame. control frames go through
+// it too, binance counts pings and pongs towards the message rate limit
+func (s *WebSocketStream) write(ctx context.Context, conn *websocket.Conn, fn func(conn *websocket.Conn) error) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	if wait := time.Until(s.lastSend.Add(wsSendInterval)); wait > 0 {
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+
+	if conn == nil {
+		s.mu.Lock()
+		conn = s.wsConn
+		s.mu.Unlock()
+		if conn == nil {
+			return errStreamNotConnected
+		}
+	}
+	err := fn(conn)
+	s.lastSend = time.Now()
+	return err
+}
+
+// wsBackoff returns the delay before a reconnect attempt, doubling from
+// wsMinBackoff up to wsMaxBackoff with up to 50% jitter
+func wsBackoff(attempt int) time.Duration {
+	delay := wsMinBackoff
+	for i := 1; i < attempt && delay < wsMaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > wsMaxBackoff {
+		delay = wsMaxBackoff
 	}
-	return msg, err
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
 }
@@ -0,0 +1,79 @@
+package binance
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// newWsServer accepts one connection and records the arrival time of every
+// text frame and ping it receives
+func newWsServer(t *testing.T) (url string, frames func() []time.Time) {
+	t.Helper()
+	var mu sync.Mutex
+	var times []time.Time
+	record := func() {
+		mu.Lock()
+		times = append(times, time.Now())
+		mu.Unlock()
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.SetPingHandler(func(string) error {
+			record()
+			return nil
+		})
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+			record()
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return "ws" + strings.TrimPrefix(srv.URL, "http"), func() []time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]time.Time(nil), times...)
+	}
+}
+
+func TestWebSocketStreamSpacesControlFrames(t *testing.T) {
+	url, frames := newWsServer(t)
+	s := newWebSocketStream(url, nopLogger{})
+	s.pingInterval = 5 * time.Millisecond
+	ctx := s.start(context.Background())
+	defer s.stop()
+	if err := s.connect(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 4; i++ {
+		if err := s.send(ctx, map[string]int{"id": i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	time.Sleep(2 * wsSendInterval)
+	s.close()
+
+	times := frames()
+	if len(times) < 6 {
+		t.Fatalf("server received %d frames, want pings and json frames", len(times))
+	}
+	// allow some scheduling jitter between the write and the read
+	for i := 1; i < len(times); i++ {
+		if gap := times[i].Sub(times[i-1]); gap < wsSendInterval-20*time.Millisecond {
+			t.Errorf("frames %d and %d arrived %v apart, want at least %v", i-1, i, gap, wsSendInterval)
+		}
+	}
+}